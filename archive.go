@@ -0,0 +1,251 @@
+package main
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/JasonKhew96/twiscraper/entity"
+	"github.com/pkg/errors"
+)
+
+// archiveSink stores the files of the archive under keys like
+// author/tweetid_01.jpg.
+type archiveSink interface {
+	put(key string, body io.Reader, size int64, contentType string) error
+}
+
+// newArchiveSink returns the sink the config asks for, or nil when nothing
+// is archived.
+func newArchiveSink(config *Config) (archiveSink, error) {
+	if config.ArchiveS3Endpoint != "" {
+		endpoint, err := url.Parse(config.ArchiveS3Endpoint)
+		if err != nil || endpoint.Host == "" {
+			return nil, errors.Errorf("ARCHIVE_S3_ENDPOINT %s is not a url", config.ArchiveS3Endpoint)
+		}
+		return &s3Sink{
+			client:    &http.Client{Timeout: 10 * time.Minute},
+			endpoint:  endpoint,
+			bucket:    config.ArchiveS3Bucket,
+			region:    config.ArchiveS3Region,
+			accessKey: config.ArchiveS3AccessKey,
+			secretKey: config.ArchiveS3SecretKey,
+		}, nil
+	}
+	if config.ArchiveDir != "" {
+		if err := os.MkdirAll(config.ArchiveDir, 0o755); err != nil {
+			return nil, err
+		}
+		return &diskSink{dir: config.ArchiveDir}, nil
+	}
+	return nil, nil
+}
+
+// diskSink writes the archive to a directory.
+type diskSink struct {
+	dir string
+}
+
+func (s *diskSink) put(key string, body io.Reader, size int64, contentType string) error {
+	dst := filepath.Join(s.dir, filepath.FromSlash(key))
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return err
+	}
+	// a file cut short never takes the place of a complete one
+	tmp, err := os.CreateTemp(filepath.Dir(dst), ".archive-*")
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(tmp, body)
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), dst); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
+
+// s3Sink puts the archive in a bucket of an S3-compatible store, addressed
+// by path so it works with MinIO and the like. Requests are signed with
+// AWS Signature Version 4.
+type s3Sink struct {
+	client    *http.Client
+	endpoint  *url.URL
+	bucket    string
+	region    string
+	accessKey string
+	secretKey string
+}
+
+func (s *s3Sink) put(key string, body io.Reader, size int64, contentType string) error {
+	u := *s.endpoint
+	u.Path = strings.TrimSuffix(u.Path, "/") + "/" + s.bucket + "/" + key
+	u.RawPath = ""
+	req, err := http.NewRequest(http.MethodPut, u.String(), body)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	req.Header.Set("Content-Type", contentType)
+	s.sign(req, time.Now().UTC())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "failed to put object")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("failed to put object %s: %s %s", key, resp.Status, msg)
+	}
+	return nil
+}
+
+// sign adds the headers of AWS Signature Version 4. The payload is left
+// unsigned, so bodies are streamed without being read twice.
+func (s *s3Sink) sign(req *http.Request, now time.Time) {
+	const payloadHash = "UNSIGNED-PAYLOAD"
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", payloadHash)
+
+	signedHeaders := "content-type;host;x-amz-content-sha256;x-amz-date"
+	canonicalHeaders := "content-type:" + req.Header.Get("Content-Type") + "\n" +
+		"host:" + req.URL.Host + "\n" +
+		"x-amz-content-sha256:" + payloadHash + "\n" +
+		"x-amz-date:" + amzDate + "\n"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders,
+		signedHeaders,
+		payloadHash,
+	}, "\n")
+	scope := date + "/" + s.region + "/s3/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.secretKey), date)
+	signingKey = hmacSHA256(signingKey, s.region)
+	signingKey = hmacSHA256(signingKey, "s3")
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKey, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	h.Write([]byte(data))
+	return h.Sum(nil)
+}
+
+// archiveSidecar is what the archive keeps about a tweet next to its files.
+type archiveSidecar struct {
+	TweetId   string    `json:"tweet_id"`
+	Url       string    `json:"url"`
+	CreatedAt time.Time `json:"created_at"`
+	Author    struct {
+		UserId     string `json:"user_id"`
+		ScreenName string `json:"screen_name"`
+	} `json:"author"`
+	Text     string   `json:"text"`
+	Hashtags []string `json:"hashtags"`
+	Metrics  struct {
+		Likes    int `json:"likes"`
+		Retweets int `json:"retweets"`
+		Quotes   int `json:"quotes"`
+		Replies  int `json:"replies"`
+		Views    int `json:"views"`
+	} `json:"metrics"`
+}
+
+func newArchiveSidecar(tweet *entity.ParsedTweet) archiveSidecar {
+	sidecar := archiveSidecar{
+		TweetId:   tweet.TweetId,
+		Url:       tweet.Url,
+		CreatedAt: tweet.CreatedAt,
+		Text:      tweet.FullText,
+		Hashtags:  tweet.Entities.Hashtags,
+	}
+	sidecar.Author.UserId = tweet.ParsedUser.UserId
+	sidecar.Author.ScreenName = tweet.ParsedUser.ScreenName
+	sidecar.Metrics.Likes = tweet.FavouriteCount
+	sidecar.Metrics.Retweets = tweet.RetweetedCount
+	sidecar.Metrics.Quotes = tweet.QuoteCount
+	sidecar.Metrics.Replies = tweet.ReplyCount
+	sidecar.Metrics.Views = tweet.Views
+	return sidecar
+}
+
+// archiveOriginals stores the original files of the tweet and its sidecar,
+// once however many channels it goes to. Files the fetcher will not take are streamed
+// straight from Twitter, the archive has no upload limit.
+func (bot *bot) archiveOriginals(c *twiCache) {
+	_, files := originalDocuments(c.tweetId, c.medias, c.positions)
+	dir := c.username
+	for _, file := range files {
+		if err := bot.archiveFile(path.Join(dir, file.name), file.url); err != nil {
+			log.Println("archiveFile error", file.url, err)
+		}
+	}
+
+	data, err := json.MarshalIndent(newArchiveSidecar(c.tweet), "", "  ")
+	if err != nil {
+		log.Println("archive sidecar error", c.tweetId, err)
+		return
+	}
+	key := path.Join(dir, c.tweetId+".json")
+	if err := bot.archive.put(key, bytes.NewReader(data), int64(len(data)), "application/json"); err != nil {
+		log.Println("archive sidecar error", c.tweetId, err)
+	}
+}
+
+func (bot *bot) archiveFile(key, url string) error {
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	f, size, err := bot.fetcher.fetch(url)
+	if err == nil {
+		defer f.Close()
+		return bot.archive.put(key, f, size, contentType)
+	}
+	var tooLarge *FileTooLargeError
+	if !errors.As(err, &tooLarge) {
+		return err
+	}
+
+	resp, err := bot.fetcher.client.Get(url)
+	if err != nil {
+		return errors.Wrap(err, "failed to download file")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK || resp.ContentLength < 0 {
+		return fmt.Errorf("failed to download file %s: %s", url, resp.Status)
+	}
+	return bot.archive.put(key, resp.Body, resp.ContentLength, contentType)
+}
@@ -4,26 +4,23 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
-	"image/jpeg"
+	"image"
 	"log"
 	"math"
-	"math/big"
 	"net/http"
-	"os"
 	"regexp"
-	"sort"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 	"twitter-bot/models"
 
-	"github.com/JasonKhew96/twiscraper"
 	"github.com/JasonKhew96/twiscraper/entity"
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers"
 	"github.com/PaulSonOfLars/gotgbot/v2/ext/handlers/filters/message"
-	"github.com/corona10/goimagehash"
 	_ "github.com/lib/pq"
 	"github.com/pkg/errors"
 	"github.com/volatiletech/null/v8"
@@ -32,14 +29,49 @@ import (
 )
 
 type twiCache struct {
+	// the tweet the medias come from, for the archive
+	tweet    *entity.ParsedTweet
 	username string
 	tweetId  string
 	medias   []entity.ParsedMedia
+	// 1-based position of each media in the tweet, for the file names
+	positions []int
+	// the first message of the post, which gets the follow prompt
+	first bool
+}
+
+// subset returns the cache for the medias at the given indexes, for the part
+// of an album that went out as one message.
+func (c *twiCache) subset(indexes []int, first bool) *twiCache {
+	sub := &twiCache{
+		tweet:    c.tweet,
+		username: c.username,
+		tweetId:  c.tweetId,
+		first:    first,
+	}
+	for _, i := range indexes {
+		sub.medias = append(sub.medias, c.medias[i])
+		sub.positions = append(sub.positions, c.positions[i])
+	}
+	return sub
 }
 
 type Job struct {
+	chatID      int64
 	inputMedias []gotgbot.InputMedia
-	cache       *twiCache
+	// where the media of each input media comes from
+	files []mediaFile
+	// text that did not fit into the caption, replied to the post
+	followUps []richText
+	cache     *twiCache
+	// fingerprint of each photo, kept once it is posted
+	fingerprints []*fingerprint
+}
+
+// cacheKey is a channel post, the NSFW channel numbers its posts on its own.
+type cacheKey struct {
+	chatID    int64
+	messageID int64
 }
 
 type SimilarJob struct {
@@ -48,25 +80,45 @@ type SimilarJob struct {
 
 type bot struct {
 	db          *sql.DB
-	twit        *twiscraper.Scraper
+	accounts    *accountPool
 	tg          *gotgbot.Bot
-	caches      map[int64]*twiCache
+	caches      map[cacheKey]*twiCache
+	cachesMu    sync.Mutex
 	jobs        chan Job
 	similarJobs chan SimilarJob
 
-	errCount int
+	errCount atomic.Int64
+
+	state   runtimeState
+	breaker *circuitBreaker
 
 	channelChatID int64
 	groupChatID   int64
 	ownerID       int64
 
+	sensitive *sensitiveFilter
+
+	duplicateMode     string
+	duplicateDistance int
+	similarity        similarityWeights
+
+	// main takes what no destination does, nsfw is only set when sensitive
+	// tweets go to a channel of their own
+	main         *destination
+	nsfw         *destination
+	destinations []*destination
+
 	moeIslandChannelID int64
 	moeIslandGroupID   int64
 
-	popularTweetFactor   int
-	popularRetweetFactor int
+	sources            []*timelineSource
+	captionTemplates   *captionTemplates
+	timelineReevaluate time.Duration
 
 	botApiUrl string
+	fetcher   *fetcher
+	// nil when nothing is archived
+	archive archiveSink
 }
 
 func New() (*bot, error) {
@@ -103,25 +155,90 @@ func New() (*bot, error) {
 			updated_at TIMESTAMP NOT NULL
 		);
 
+		-- older databases: migrations/images_hash.sql, then
+		-- migrations/images_fingerprints.sql
 		CREATE TABLE images (
 			id SERIAL NOT NULL UNIQUE PRIMARY KEY,
-			hash_a TEXT NOT NULL,
-			hash_b TEXT NOT NULL,
-			hash_c TEXT NOT NULL,
-			hash_d TEXT NOT NULL,
+			hash BIGINT NOT NULL,
+			d_hash BIGINT,
+			a_hash BIGINT,
+			histogram BYTEA,
 			chat_id BIGINT NOT NULL,
 			message_id BIGINT NOT NULL,
 			created_at TIMESTAMP NOT NULL,
 			updated_at TIMESTAMP NOT NULL
 		);
+		CREATE INDEX images_chat_id_idx ON images (chat_id);
+
+		CREATE TABLE timeline_cursors (
+			name TEXT NOT NULL UNIQUE PRIMARY KEY,
+			tweet_id BIGINT NOT NULL,
+			created_at TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP NOT NULL
+		);
+
+		CREATE TABLE file_ids (
+			id SERIAL NOT NULL UNIQUE PRIMARY KEY,
+			url TEXT NOT NULL,
+			variant TEXT NOT NULL,
+			file_id TEXT NOT NULL,
+			created_at TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP NOT NULL,
+			UNIQUE (url, variant)
+		);
+
+		-- older databases: migrations/posts_fingerprinted.sql
+		CREATE TABLE posts (
+			id SERIAL NOT NULL UNIQUE PRIMARY KEY,
+			tweet_id BIGINT NOT NULL,
+			chat_id BIGINT NOT NULL,
+			message_id BIGINT NOT NULL,
+			fingerprinted BOOLEAN NOT NULL DEFAULT false,
+			created_at TIMESTAMP NOT NULL,
+			updated_at TIMESTAMP NOT NULL,
+			UNIQUE (tweet_id, chat_id)
+		);
 	*/
 
-	twit, err := twiscraper.New(&twiscraper.ScraperOptions{
-		Delay:      3 * time.Second,
-		Cookie:     config.TwitterCookie,
-		XCsrfToken: config.XCsrfToken,
-		Timeout:    time.Minute,
-	})
+	accounts, err := newAccountPool(config.TwitterAccounts, config.TwitterCurator, config.GraphQLQueryIds)
+	if err != nil {
+		return nil, err
+	}
+
+	sources, err := newTimelineSources(config)
+	if err != nil {
+		return nil, err
+	}
+
+	captionTemplates, err := newCaptionTemplates(config.CaptionTemplates)
+	if err != nil {
+		return nil, err
+	}
+
+	destinations, err := newDestinations(config.Destinations)
+	if err != nil {
+		return nil, err
+	}
+	mainDestination := &destination{
+		name:          "main",
+		channelChatID: config.ChannelChatID,
+		groupChatID:   config.GroupChatID,
+	}
+	var nsfw *destination
+	if config.SensitiveMode == sensitiveChannel {
+		nsfw = &destination{
+			name:          "nsfw",
+			channelChatID: config.NsfwChannelChatID,
+			groupChatID:   config.NsfwGroupChatID,
+		}
+	}
+
+	mediaCache, err := newMediaCache(config.DownloadDir, config.MediaCacheSize)
+	if err != nil {
+		return nil, err
+	}
+
+	archive, err := newArchiveSink(config)
 	if err != nil {
 		return nil, err
 	}
@@ -141,21 +258,32 @@ func New() (*bot, error) {
 	}
 
 	return &bot{
-		db:                   db,
-		twit:                 twit,
-		tg:                   b,
-		caches:               make(map[int64]*twiCache),
-		jobs:                 make(chan Job),
-		similarJobs:          make(chan SimilarJob),
-		errCount:             0,
-		channelChatID:        config.ChannelChatID,
-		groupChatID:          config.GroupChatID,
-		ownerID:              config.OwnerID,
-		moeIslandChannelID:   config.MoeIslandChannelID,
-		moeIslandGroupID:     config.MoeIslandGroupID,
-		popularTweetFactor:   config.PopularTweetFactor,
-		popularRetweetFactor: config.PopularRetweetFactor,
-		botApiUrl:            config.BotApiUrl,
+		db:                 db,
+		accounts:           accounts,
+		tg:                 b,
+		caches:             make(map[cacheKey]*twiCache),
+		jobs:               make(chan Job, 100),
+		similarJobs:        make(chan SimilarJob),
+		state:              runtimeState{startedAt: time.Now()},
+		breaker:            newCircuitBreaker(5*time.Minute, 3*time.Hour),
+		channelChatID:      config.ChannelChatID,
+		groupChatID:        config.GroupChatID,
+		ownerID:            config.OwnerID,
+		sensitive:          newSensitiveFilter(config.SensitiveMode, config.SensitiveKeywords, config.SensitiveHashtags),
+		duplicateMode:      config.DuplicateMode,
+		duplicateDistance:  config.DuplicateDistance,
+		similarity:         newSimilarityWeights(config.Similarity),
+		main:               mainDestination,
+		nsfw:               nsfw,
+		destinations:       destinations,
+		moeIslandChannelID: config.MoeIslandChannelID,
+		moeIslandGroupID:   config.MoeIslandGroupID,
+		sources:            sources,
+		captionTemplates:   captionTemplates,
+		timelineReevaluate: config.TimelineReevaluate,
+		botApiUrl:          config.BotApiUrl,
+		fetcher:            newFetcher(config.DownloadLimit, config.DownloadMemoryLimit, mediaCache),
+		archive:            archive,
 	}, nil
 }
 
@@ -175,13 +303,19 @@ func (bot *bot) initBot() error {
 	updater := ext.NewUpdater(dispatcher, nil)
 
 	dispatcher.AddHandler(handlers.NewMessage(func(msg *gotgbot.Message) bool {
-		return msg.Chat.Id == bot.groupChatID
+		return bot.isGroup(msg.Chat.Id)
 	}, bot.handleChatMessages))
 	dispatcher.AddHandler(handlers.NewCommand("follow", bot.commandFollow))
 	dispatcher.AddHandler(handlers.NewCommand("unfollow", bot.commandUnfollow))
+	dispatcher.AddHandler(handlers.NewCommand("pause", bot.commandPause))
+	dispatcher.AddHandler(handlers.NewCommand("resume", bot.commandResume))
+	dispatcher.AddHandler(handlers.NewCommand("status", bot.commandStatus))
+	dispatcher.AddHandler(handlers.NewCommand("setcookie", bot.commandSetCookie))
+	dispatcher.AddHandler(handlers.NewCommand("preview", bot.commandPreview))
+	dispatcher.AddHandler(handlers.NewCommand("backfill", bot.commandBackfill))
 
 	dispatcher.AddHandler(handlers.NewMessage(func(msg *gotgbot.Message) bool {
-		return message.Channel(msg) && msg.Chat.Id == bot.moeIslandChannelID && msg.Photo != nil
+		return message.Channel(msg) && msg.Chat.Id == bot.moeIslandChannelID && messageImage(msg) != ""
 	}, bot.handleMoeIslandMessages).SetAllowChannel(true))
 
 	dispatcher.AddHandler(handlers.NewMessage(message.Private, bot.handlePrivateMessages))
@@ -212,42 +346,29 @@ func (bot *bot) initBot() error {
 
 func (bot *bot) worker() {
 	for job := range bot.jobs {
-		var msgs []gotgbot.Message
-		var err error
-		if len(job.inputMedias) > 1 {
-			msgs, err = bot.tg.SendMediaGroup(bot.channelChatID, job.inputMedias, nil)
-		} else {
-			var msg *gotgbot.Message
-			switch job.inputMedias[0].(type) {
-			case gotgbot.InputMediaPhoto:
-				caption := job.inputMedias[0].(gotgbot.InputMediaPhoto).Caption
-				msg, err = bot.tg.SendPhoto(bot.channelChatID, job.inputMedias[0].GetMedia(), &gotgbot.SendPhotoOpts{
-					Caption:   caption,
-					ParseMode: "MarkdownV2",
-				})
-			case gotgbot.InputMediaVideo:
-				caption := job.inputMedias[0].(gotgbot.InputMediaVideo).Caption
-				msg, err = bot.tg.SendVideo(bot.channelChatID, job.inputMedias[0].GetMedia(), &gotgbot.SendVideoOpts{
-					Caption:   caption,
-					ParseMode: "MarkdownV2",
-				})
-			case gotgbot.InputMediaAnimation:
-				caption := job.inputMedias[0].(gotgbot.InputMediaAnimation).Caption
-				msg, err = bot.tg.SendAnimation(bot.channelChatID, job.inputMedias[0].GetMedia(), &gotgbot.SendAnimationOpts{
-					Caption:   caption,
-					ParseMode: "MarkdownV2",
-				})
-			default:
-				log.Println("unknown media type ", job.inputMedias[0])
-				bot.tg.SendMessage(bot.ownerID, fmt.Sprintf("unknown media type in worker\n\n%+v", job.inputMedias), nil)
-			}
-			msgs = append(msgs, *msg)
+		for bot.state.isPaused(pausePublish) {
+			time.Sleep(10 * time.Second)
 		}
+		groups, err := bot.sendMediaGroups(job.chatID, job.inputMedias, job.files, 0)
 		if err != nil {
 			log.Println(err)
 			bot.tg.SendMessage(bot.ownerID, fmt.Sprintf("%+v\n\n%+v", err.Error(), job.inputMedias), nil)
-		} else if len(job.cache.medias) > 0 {
-			bot.caches[msgs[0].MessageId] = job.cache
+		} else {
+			if err := bot.insertPost(job.cache.tweetId, job.chatID, groups[0].messages[0].MessageId); err != nil {
+				log.Println("insertPost error", err)
+			}
+			bot.insertImages(job.chatID, groups, job.fingerprints)
+			if err := bot.sendFollowUps(job.chatID, groups[0].messages[0].MessageId, job.followUps); err != nil {
+				log.Println(err)
+			}
+		}
+		// every album is forwarded to the discussion group on its own
+		if len(job.cache.medias) > 0 {
+			bot.cachesMu.Lock()
+			for i, group := range groups {
+				bot.caches[cacheKey{job.chatID, group.messages[0].MessageId}] = job.cache.subset(group.indexes, i == 0)
+			}
+			bot.cachesMu.Unlock()
 		}
 		time.Sleep(10 * time.Second)
 	}
@@ -274,7 +395,7 @@ func (bot *bot) handleCallbackData(b *gotgbot.Bot, ctx *ext.Context) error {
 	username := strings.Split(ctx.CallbackQuery.Data, ".")[1]
 	switch {
 	case strings.HasPrefix(ctx.CallbackQuery.Data, "follow."):
-		profile, err := bot.twit.GetUserByScreenName(username)
+		profile, err := bot.accounts.curator.get().GetUserByScreenName(username)
 		if err != nil {
 			_, err := ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{
 				Text:      fmt.Sprintf("Error GetProfile %s", err.Error()),
@@ -300,7 +421,7 @@ func (bot *bot) handleCallbackData(b *gotgbot.Bot, ctx *ext.Context) error {
 			})
 			return err
 		}
-		if err := bot.twit.Follow(profile.ScreenName); err != nil {
+		if err := bot.accounts.curator.get().Follow(profile.ScreenName); err != nil {
 			_, err := ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{
 				Text:      fmt.Sprintf("Error Follow %s", err.Error()),
 				ShowAlert: true,
@@ -315,7 +436,7 @@ func (bot *bot) handleCallbackData(b *gotgbot.Bot, ctx *ext.Context) error {
 		})
 		return err
 	case strings.HasPrefix(ctx.CallbackQuery.Data, "unfollow."):
-		profile, err := bot.twit.GetUserByScreenName(username)
+		profile, err := bot.accounts.curator.get().GetUserByScreenName(username)
 		if err != nil {
 			_, err := ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{
 				Text:      fmt.Sprintf("Error GetProfile %s", err.Error()),
@@ -339,7 +460,7 @@ func (bot *bot) handleCallbackData(b *gotgbot.Bot, ctx *ext.Context) error {
 		if err := t.Insert(context.Background(), bot.db, boil.Infer()); err != nil {
 			bot.tg.SendMessage(bot.ownerID, fmt.Sprintf("Error Insert %s", err.Error()), nil)
 		}
-		if err := bot.twit.UnFollow(profile.ScreenName); err != nil {
+		if err := bot.accounts.curator.get().UnFollow(profile.ScreenName); err != nil {
 			_, err := ctx.CallbackQuery.Answer(b, &gotgbot.AnswerCallbackQueryOpts{
 				Text:      fmt.Sprintf("Error Unfollow %s", err.Error()),
 				ShowAlert: true,
@@ -361,90 +482,43 @@ func (bot *bot) handleCallbackData(b *gotgbot.Bot, ctx *ext.Context) error {
 }
 
 type HashImage struct {
-	ChatID    int64
-	MessageID int64
-	Distance  int
+	ChatID     int64
+	MessageID  int64
+	Similarity similarity
 }
 
 func (bot *bot) handleMoeIslandMessages(b *gotgbot.Bot, ctx *ext.Context) error {
-	photo := ctx.EffectiveMessage.Photo[len(ctx.EffectiveMessage.Photo)-1]
-	botFile, err := b.GetFile(photo.FileId, nil)
+	// every item of an album comes as a message of its own
+	file, err := bot.openTelegramFile(messageImage(ctx.EffectiveMessage))
 	if err != nil {
 		return err
 	}
-	filePath := botFile.FilePath
-	file, err := os.Open(filePath)
+	img, _, err := image.Decode(file)
+	file.Close()
 	if err != nil {
-		return err
-	}
-	img, err := jpeg.Decode(file)
-	if err != nil {
-		return err
+		return errors.Wrap(err, "failed to decode image")
 	}
-	newPHash, err := goimagehash.PerceptionHash(img)
+	fp, err := newFingerprint(img)
 	if err != nil {
 		return err
 	}
 
-	fmtStr := "%016x"
-	hashStr := fmt.Sprintf(fmtStr, newPHash.GetHash())
-	hashA := hashStr[:4]
-	hashB := hashStr[4:8]
-	hashC := hashStr[8:12]
-	hashD := hashStr[12:16]
-
-	newImg := models.Image{
-		HashA:     hashA,
-		HashB:     hashB,
-		HashC:     hashC,
-		HashD:     hashD,
-		ChatID:    ctx.EffectiveChat.Id,
-		MessageID: ctx.EffectiveMessage.MessageId,
-	}
-
-	allImgs, err := models.Images(qm.Where("chat_id = ? AND (hash_a = ? OR hash_b = ? OR hash_c = ? OR hash_d = ?)", ctx.EffectiveChat.Id, hashA, hashB, hashC, hashD)).All(context.Background(), bot.db)
+	images, err := bot.findSimilarImages(ctx.EffectiveChat.Id, fp, bot.duplicateDistance)
 	if err != nil {
 		return err
 	}
 
-	if err := newImg.Insert(context.Background(), bot.db, boil.Infer()); err != nil {
+	if err := bot.insertImage(ctx.EffectiveChat.Id, ctx.EffectiveMessage.MessageId, fp); err != nil {
 		return err
 	}
 
-	var images []HashImage
-
-	for _, img := range allImgs {
-		oldPHashStr := fmt.Sprintf("%s%s%s%s", img.HashA, img.HashB, img.HashC, img.HashD)
-		n := new(big.Int)
-		n.SetString(oldPHashStr, 16)
-		oldPHash := goimagehash.NewImageHash(n.Uint64(), goimagehash.PHash)
-		distance, err := oldPHash.Distance(newPHash)
-		if err != nil {
-			return err
-		}
-		if distance > 5 {
-			continue
-		}
-		images = append(images, HashImage{
-			ChatID:    img.ChatID,
-			MessageID: img.MessageID,
-			Distance:  distance,
-		})
-	}
-
 	if len(images) <= 0 {
 		return nil
 	}
 
-	sort.Slice(images, func(i, j int) bool {
-		return images[i].Distance < images[j].Distance
-	})
-
-	chatId := strings.TrimPrefix(fmt.Sprintf("%d", ctx.EffectiveChat.Id), "-100")
-	fmtMessage := fmt.Sprintf("Similar images:\nhttps://t.me/c/%s/%d\n", chatId, ctx.EffectiveMessage.MessageId)
+	fmtMessage := fmt.Sprintf("Similar images:\n%s\n", postLink(ctx.EffectiveChat.Id, ctx.EffectiveMessage.MessageId))
 	for _, img := range images {
-		imgChatId := strings.TrimPrefix(fmt.Sprintf("%d", img.ChatID), "-100")
-		fmtMessage += fmt.Sprintf("https://t.me/c/%s/%d %d\n", imgChatId, img.MessageID, img.Distance)
+		fmtMessage += fmt.Sprintf("%s %s\n", postLink(img.ChatID, img.MessageID), img.Similarity)
 	}
 	bot.similarJobs <- SimilarJob{msg: fmtMessage}
 
@@ -468,108 +542,49 @@ func (bot *bot) handlePrivateMessages(b *gotgbot.Bot, ctx *ext.Context) error {
 		return nil
 	}
 	tweetID := url.TweetID
-	tweet, err := bot.twit.GetTweetDetail(tweetID)
+	tweet, err := bot.accounts.any().get().GetTweetDetail(tweetID)
 	if err != nil {
 		_, err = ctx.EffectiveMessage.Reply(b, err.Error(), nil)
 		return err
 	}
-	caption := tweet2Caption(tweet)
-	inputMedias := tweet2InputMedias(tweet, caption)
-
-	if len(inputMedias) > 1 {
-		_, err = b.SendMediaGroup(ctx.EffectiveChat.Id, inputMedias, nil)
-	} else if len(inputMedias) == 1 {
-		inputMedia := inputMedias[0]
-		switch media := inputMedia.(type) {
-		case gotgbot.InputMediaPhoto:
-			_, err = b.SendPhoto(ctx.EffectiveChat.Id, inputMedia.GetMedia(), &gotgbot.SendPhotoOpts{
-				Caption:   media.Caption,
-				ParseMode: "MarkdownV2",
-				ReplyParameters: &gotgbot.ReplyParameters{
-					MessageId: ctx.EffectiveMessage.MessageId,
-				},
-			})
-		case gotgbot.InputMediaVideo:
-			_, err = b.SendVideo(ctx.EffectiveChat.Id, inputMedia.GetMedia(), &gotgbot.SendVideoOpts{
-				Caption:   media.Caption,
-				Width:     media.Width,
-				Height:    media.Height,
-				Duration:  media.Duration,
-				Cover:     gotgbot.InputFileByURL(media.Cover),
-				ParseMode: "MarkdownV2",
-				ReplyParameters: &gotgbot.ReplyParameters{
-					MessageId: ctx.EffectiveMessage.MessageId,
-				},
-			})
-		case gotgbot.InputMediaAnimation:
-			_, err = b.SendAnimation(ctx.EffectiveChat.Id, inputMedia.GetMedia(), &gotgbot.SendAnimationOpts{
-				Caption:   media.Caption,
-				Width:     media.Width,
-				Height:    media.Height,
-				Duration:  media.Duration,
-				ParseMode: "MarkdownV2",
-				ReplyParameters: &gotgbot.ReplyParameters{
-					MessageId: ctx.EffectiveMessage.MessageId,
-				},
-			})
+	caption, followUps := tweet2Caption(tweet, bot.captionTemplates.get(ctx.EffectiveChat.Id), richText{})
+	// sent to whoever asked, so the sensitive mode only decides the spoiler
+	inputMedias, files := tweet2InputMedias(tweet, caption, bot.sensitive.match(tweet))
+
+	var replyTo int64
+	if len(inputMedias) > 0 {
+		var groups []sentMediaGroup
+		groups, err = bot.sendMediaGroups(ctx.EffectiveChat.Id, inputMedias, files, ctx.EffectiveMessage.MessageId)
+		if err == nil {
+			replyTo = groups[0].messages[0].MessageId
 		}
 	} else {
-		_, err = b.SendMessage(ctx.EffectiveChat.Id, caption, &gotgbot.SendMessageOpts{
+		var msg *gotgbot.Message
+		msg, err = b.SendMessage(ctx.EffectiveChat.Id, caption.text, &gotgbot.SendMessageOpts{
 			LinkPreviewOptions: &gotgbot.LinkPreviewOptions{
 				IsDisabled: true,
 			},
-			ParseMode: "MarkdownV2",
+			Entities: caption.entities,
 			ReplyParameters: &gotgbot.ReplyParameters{
 				MessageId: ctx.EffectiveMessage.MessageId,
 			},
 		})
+		if err == nil {
+			replyTo = msg.MessageId
+		}
 	}
 	if err != nil {
 		log.Println(err)
 		_, err = ctx.EffectiveMessage.Reply(b, err.Error(), nil)
 		return err
 	}
+	if err := bot.sendFollowUps(ctx.EffectiveChat.Id, replyTo, followUps); err != nil {
+		log.Println(err)
+	}
 
 	if len(tweet.Entities.Media) > 0 {
-		var inputMedia []gotgbot.InputMedia
-		for i, media := range tweet.Entities.Media {
-			var newUrl string
-			var fn string
-			switch v := media.(type) {
-			case entity.ParsedMediaPhoto:
-				newUrl = clearUrlQueries(v.Url)
-				splits := strings.Split(newUrl, ".")
-				ext := splits[len(splits)-1]
-				fn = fmt.Sprintf("%s_%02d.%s", tweet.TweetId, i+1, ext)
-				if ext == "jpg" || ext == "jpeg" || ext == "png" {
-					newUrl = strings.TrimSuffix(newUrl, "."+ext) + "?format=" + ext + "&name=orig"
-				}
-			case entity.ParsedMediaVideo:
-				newUrl = clearUrlQueries(v.Url)
-				splits := strings.Split(newUrl, ".")
-				ext := splits[len(splits)-1]
-				fn = fmt.Sprintf("%s_%02d.%s", tweet.TweetId, i+1, ext)
-			}
-
-			var media gotgbot.InputFileOrString
-			buf, err := downloadToBuffer(newUrl, fn)
-			if err != nil {
-				log.Println(err)
-				media = gotgbot.InputFileByURL(newUrl)
-			} else {
-				media = buf
-			}
-
-			inputMedia = append(inputMedia, gotgbot.InputMediaDocument{
-				Caption: newUrl,
-				Media:   media,
-			})
-		}
-		if _, err := b.SendMediaGroup(ctx.Message.Chat.Id, inputMedia, &gotgbot.SendMediaGroupOpts{
-			ReplyParameters: &gotgbot.ReplyParameters{
-				MessageId: ctx.EffectiveMessage.MessageId,
-			},
-		}); err != nil {
+		inputMedia, files := originalDocuments(tweet.TweetId, tweet.Entities.Media, nil)
+		if _, err := bot.sendMediaGroups(ctx.Message.Chat.Id, inputMedia, files, ctx.EffectiveMessage.MessageId); err != nil {
 			log.Println(err)
 			_, err = bot.tg.SendMessage(bot.ownerID, fmt.Sprintf("%+v\n\n%+v\n\n%s", err.Error(), inputMedia, ctx.Message.Entities[len(ctx.Message.Entities)-1].Url), nil)
 			return err
@@ -580,56 +595,29 @@ func (bot *bot) handlePrivateMessages(b *gotgbot.Bot, ctx *ext.Context) error {
 }
 
 func (bot *bot) handleChatMessages(b *gotgbot.Bot, ctx *ext.Context) error {
-	if !ctx.Message.IsAutomaticForward && ctx.Message.SenderChat.Id != bot.channelChatID {
+	if !ctx.Message.IsAutomaticForward && !bot.isChannel(ctx.Message.SenderChat.Id) {
 		return nil
 	}
 	messageOrigin := ctx.Message.ForwardOrigin.MergeMessageOrigin()
-	if c, ok := bot.caches[messageOrigin.MessageId]; ok {
-		defer delete(bot.caches, messageOrigin.MessageId)
+	if messageOrigin.Chat == nil {
+		return nil
+	}
+	key := cacheKey{messageOrigin.Chat.Id, messageOrigin.MessageId}
+	bot.cachesMu.Lock()
+	c, ok := bot.caches[key]
+	delete(bot.caches, key)
+	bot.cachesMu.Unlock()
+	if ok {
 		if len(c.medias) > 0 {
-			var inputMedia []gotgbot.InputMedia
-			for i, media := range c.medias {
-				var newUrl string
-				var fn string
-				switch v := media.(type) {
-				case entity.ParsedMediaPhoto:
-					newUrl = clearUrlQueries(v.Url)
-					splits := strings.Split(newUrl, ".")
-					ext := splits[len(splits)-1]
-					fn = fmt.Sprintf("%s_%02d.%s", c.tweetId, i+1, ext)
-					if ext == "jpg" || ext == "jpeg" || ext == "png" {
-						newUrl = strings.TrimSuffix(newUrl, "."+ext) + "?format=" + ext + "&name=orig"
-					}
-				case entity.ParsedMediaVideo:
-					newUrl = clearUrlQueries(v.Url)
-					splits := strings.Split(newUrl, ".")
-					ext := splits[len(splits)-1]
-					fn = fmt.Sprintf("%s_%02d.%s", c.tweetId, i+1, ext)
-				}
-
-				var media gotgbot.InputFileOrString
-				buf, err := downloadToBuffer(newUrl, fn)
-				if err != nil {
-					log.Println(err)
-					media = gotgbot.InputFileByURL(newUrl)
-				} else {
-					media = buf
-				}
-
-				inputMedia = append(inputMedia, gotgbot.InputMediaDocument{
-					Caption: newUrl,
-					Media:   media,
-				})
-			}
-			if _, err := b.SendMediaGroup(ctx.Message.Chat.Id, inputMedia, &gotgbot.SendMediaGroupOpts{
-				ReplyParameters: &gotgbot.ReplyParameters{
-					MessageId: ctx.EffectiveMessage.MessageId,
-				},
-			}); err != nil {
+			inputMedia, files := originalDocuments(c.tweetId, c.medias, c.positions)
+			if _, err := bot.sendMediaGroups(ctx.Message.Chat.Id, inputMedia, files, ctx.EffectiveMessage.MessageId); err != nil {
 				log.Println(err)
 				_, err = bot.tg.SendMessage(bot.ownerID, fmt.Sprintf("%+v\n\n%+v\n\n%s", err.Error(), inputMedia, ctx.Message.Entities[len(ctx.Message.Entities)-1].Url), nil)
 				return err
 			}
+			if !c.first {
+				return nil
+			}
 			if _, err := bot.tg.SendMessage(ctx.Message.Chat.Id, fmt.Sprintf("https://x.com/%s", c.username), &gotgbot.SendMessageOpts{
 				ReplyParameters: &gotgbot.ReplyParameters{
 					MessageId: ctx.EffectiveMessage.MessageId,
@@ -683,7 +671,7 @@ func (bot *bot) commandFollow(b *gotgbot.Bot, ctx *ext.Context) error {
 		return err
 	}
 
-	profile, err := bot.twit.GetUserByScreenName(twitterUrl.Username)
+	profile, err := bot.accounts.curator.get().GetUserByScreenName(twitterUrl.Username)
 	if err != nil {
 		_, err = ctx.EffectiveMessage.Reply(b, fmt.Sprintf("Error GetProfile %s", err.Error()), nil)
 		return err
@@ -700,7 +688,7 @@ func (bot *bot) commandFollow(b *gotgbot.Bot, ctx *ext.Context) error {
 		return err
 	}
 
-	if err := bot.twit.Follow(profile.ScreenName); err != nil {
+	if err := bot.accounts.curator.get().Follow(profile.ScreenName); err != nil {
 		_, err = ctx.EffectiveMessage.Reply(b, fmt.Sprintf("Error %s", err.Error()), nil)
 		return err
 	}
@@ -750,7 +738,7 @@ func (bot *bot) commandUnfollow(b *gotgbot.Bot, ctx *ext.Context) error {
 		return err
 	}
 
-	profile, err := bot.twit.GetUserByScreenName(twitterUrl.Username)
+	profile, err := bot.accounts.curator.get().GetUserByScreenName(twitterUrl.Username)
 	if err != nil {
 		_, err = ctx.EffectiveMessage.Reply(b, fmt.Sprintf("Error GetProfile %s", err.Error()), nil)
 		return err
@@ -769,7 +757,7 @@ func (bot *bot) commandUnfollow(b *gotgbot.Bot, ctx *ext.Context) error {
 		ctx.EffectiveMessage.Reply(b, fmt.Sprintf("Error %s", err.Error()), nil)
 	}
 
-	if err := bot.twit.UnFollow(profile.ScreenName); err != nil {
+	if err := bot.accounts.curator.get().UnFollow(profile.ScreenName); err != nil {
 		_, err = ctx.EffectiveMessage.Reply(b, fmt.Sprintf("Error %s", err.Error()), nil)
 		return err
 	}
@@ -843,11 +831,26 @@ func isMedia(tweet entity.ParsedTweet) bool {
 	return len(tweet.Entities.Media) > 0
 }
 
-func (bot *bot) isPopularRetweet(t time.Time, likes int) bool {
+// popularity holds the like thresholds a tweet has to reach, per hour since
+// it was posted, to be picked up from a timeline.
+type popularity struct {
+	tweetFactor   int
+	retweetFactor int
+	quoteFactor   int
+}
+
+// forQuote applies the quote factor where a retweet would use the retweet
+// factor.
+func (p popularity) forQuote() popularity {
+	p.retweetFactor = p.quoteFactor
+	return p
+}
+
+func (p popularity) isPopularRetweet(t time.Time, likes int) bool {
 	sinceHours := int(math.Floor(time.Since(t).Hours()))
 
 	for h := 1; h <= 24*7; h++ {
-		if sinceHours <= h && likes >= h*bot.popularRetweetFactor {
+		if sinceHours <= h && likes >= h*p.retweetFactor {
 			return true
 		}
 	}
@@ -855,11 +858,11 @@ func (bot *bot) isPopularRetweet(t time.Time, likes int) bool {
 	return false
 }
 
-func (bot *bot) isPopularTweet(t time.Time, likes int) bool {
+func (p popularity) isPopularTweet(t time.Time, likes int) bool {
 	sinceHours := int(math.Floor(time.Since(t).Hours()))
 
 	for h := 1; h <= 24*7; h++ {
-		if sinceHours <= h && likes >= h*bot.popularTweetFactor {
+		if sinceHours <= h && likes >= h*p.tweetFactor {
 			return true
 		}
 	}
@@ -867,6 +870,30 @@ func (bot *bot) isPopularTweet(t time.Time, likes int) bool {
 	return false
 }
 
+func (p popularity) mayBePopular(tweet *entity.ParsedTweet) bool {
+	if tweet.IsRetweet {
+		tweet = tweet.RetweetedTweet
+	}
+	if quoted := quotedMedia(tweet); quoted != nil {
+		return p.forQuote().mayBePopular(quoted)
+	}
+	return p.isPopularTweet(tweet.CreatedAt, tweet.FavouriteCount) || p.isPopularRetweet(tweet.CreatedAt, tweet.FavouriteCount)
+}
+
+// quotedMedia returns the quoted tweet when a tweet without media of its own
+// quotes someone else's media. twiscraper keeps the quoted tweet in
+// IsReply and RepliedTweet.
+func quotedMedia(tweet *entity.ParsedTweet) *entity.ParsedTweet {
+	if tweet.IsRetweet || isMedia(*tweet) || !tweet.IsReply || tweet.RepliedTweet == nil {
+		return nil
+	}
+	quoted := tweet.RepliedTweet
+	if !isMedia(*quoted) || quoted.ParsedUser.UserId == tweet.ParsedUser.UserId {
+		return nil
+	}
+	return quoted
+}
+
 func isRepost(tweet *entity.ParsedTweet) bool {
 	forbiddenHashTags := []string{
 		"フォロー",
@@ -940,7 +967,12 @@ func isIllustratorOrAnimator(text string) bool {
 	return false
 }
 
-func (bot *bot) processRetweet(tweet *entity.ParsedTweet, retweetUserId string) (bool, error) {
+// processRetweet handles a tweet shared by someone else, either retweeted or
+// quoted. quote is the quoting tweet, nil for retweets. follow tells whether
+// the curator may follow the author: the source has to allow it, and the
+// curator has to be the one that read the tweet, as IsFollowing is of the
+// account that did.
+func (bot *bot) processRetweet(tweet *entity.ParsedTweet, retweetUserId string, quote *entity.ParsedTweet, pop popularity, follow bool) (bool, error) {
 	id, err := strconv.ParseInt(tweet.TweetId, 10, 64)
 	if err != nil {
 		return false, err
@@ -969,14 +1001,18 @@ OutsideLoop:
 		}
 	}
 
-	if isMentioned {
-		if !bot.isPopularTweet(tweet.CreatedAt, tweet.FavouriteCount) {
-			return false, nil
+	// destinations apply their own factors the same way
+	popular := func(p popularity) bool {
+		if quote != nil {
+			p = p.forQuote()
 		}
-	} else {
-		if !bot.isPopularRetweet(tweet.CreatedAt, tweet.FavouriteCount) {
-			return false, nil
+		if isMentioned {
+			return p.isPopularTweet(tweet.CreatedAt, tweet.FavouriteCount)
 		}
+		return p.isPopularRetweet(tweet.CreatedAt, tweet.FavouriteCount)
+	}
+	if !popular(pop) {
+		return false, nil
 	}
 
 	if !isMentioned {
@@ -995,7 +1031,7 @@ OutsideLoop:
 			return false, nil
 		}
 
-		if !tweet.ParsedUser.IsFollowing {
+		if follow && !tweet.ParsedUser.IsFollowing {
 			log.Println("Suggest", tweet.FavouriteCount, tweet.Views, tweet.Url)
 			if _, err := bot.tg.SendMessage(bot.ownerID, fmt.Sprintf("Followed https://x.com/%s", tweet.ParsedUser.ScreenName), &gotgbot.SendMessageOpts{
 				ReplyMarkup: gotgbot.InlineKeyboardMarkup{
@@ -1015,7 +1051,7 @@ OutsideLoop:
 			}); err != nil {
 				log.Println(err)
 			}
-			if err := bot.twit.Follow(tweet.ParsedUser.ScreenName); err != nil {
+			if err := bot.accounts.curator.get().Follow(tweet.ParsedUser.ScreenName); err != nil {
 				return false, err
 			}
 		}
@@ -1031,23 +1067,16 @@ OutsideLoop:
 
 	log.Println("retweet", tweet.FavouriteCount, tweet.Views, tweet.Url)
 
-	caption := tweet2Caption(tweet)
-	inputMedias := tweet2InputMedias(tweet, caption)
-
-	bot.jobs <- Job{
-		inputMedias: inputMedias,
-		cache: &twiCache{
-			username: tweet.ParsedUser.ScreenName,
-			tweetId:  tweet.TweetId,
-			medias:   tweet.Entities.Media,
-		},
-	}
+	bot.publishTweet(tweet, quote, popular)
 
 	return true, nil
 }
 
-func (bot *bot) processTweet(tweet *entity.ParsedTweet) (bool, error) {
-	if !bot.isPopularTweet(tweet.CreatedAt, tweet.FavouriteCount) {
+func (bot *bot) processTweet(tweet *entity.ParsedTweet, pop popularity) (bool, error) {
+	popular := func(p popularity) bool {
+		return p.isPopularTweet(tweet.CreatedAt, tweet.FavouriteCount)
+	}
+	if !popular(pop) {
 		return false, nil
 	}
 
@@ -1068,85 +1097,99 @@ func (bot *bot) processTweet(tweet *entity.ParsedTweet) (bool, error) {
 
 	log.Println("tweet", tweet.FavouriteCount, tweet.Views, tweet.Url)
 
-	caption := tweet2Caption(tweet)
-	inputMedias := tweet2InputMedias(tweet, caption)
-
-	bot.jobs <- Job{
-		inputMedias: inputMedias,
-		cache: &twiCache{
-			username: tweet.ParsedUser.ScreenName,
-			tweetId:  tweet.TweetId,
-			medias:   tweet.Entities.Media,
-		},
-	}
+	bot.publishTweet(tweet, nil, popular)
 
 	return true, nil
 }
 
 func (bot *bot) newLoop() (int, error) {
 	var count int
-	for tweet := range bot.twit.GetHomeTimeline(context.Background(), 5*20) {
-		if tweet.Error != nil {
-			bot.errCount++
-			log.Println("GetHomeTimeline error", tweet.Error)
-			bot.tg.SendMessage(bot.ownerID, "GetHomeTimeline error", nil)
-			time.Sleep(time.Minute)
-			break
-		}
-		bot.errCount = 0
-
-		if !isMedia(tweet.ParsedTweet) {
+	var scraped bool
+	for _, source := range bot.sources {
+		if !source.isDue() {
 			continue
 		}
-
-		if tweet.ParsedTweet.IsRetweet && tweet.ParsedTweet.RetweetedTweet.ParsedUser.UserId == tweet.ParsedTweet.ParsedUser.UserId {
-			continue
+		if scraped {
+			time.Sleep(5 * time.Second)
+		}
+		scraped = true
+		n, err := bot.scrapeTimeline(source)
+		if err != nil {
+			return -1, err
+		}
+		source.lastRun = time.Now()
+		count += n
+		if bot.errCount.Load() > 5 {
+			return -1, fmt.Errorf("TOO MUCH ERROR")
 		}
+	}
+	return count, nil
+}
 
-		if tweet.ParsedTweet.IsRetweet {
-			_, err := bot.processRetweet(tweet.ParsedTweet.RetweetedTweet, tweet.ParsedTweet.ParsedUser.UserId)
-			if err != nil {
-				bot.errCount++
-				log.Println("processRetweet error", tweet.ParsedTweet.Url, err)
-				bot.tg.SendMessage(bot.ownerID, fmt.Sprintf("processRetweet error %s", tweet.ParsedTweet.Url), nil)
-				time.Sleep(time.Minute)
-				continue
+// scrapeTimeline reads a timeline with the next available account and fails
+// over to the other accounts when one is logged out or erroring.
+func (bot *bot) scrapeTimeline(source *timelineSource) (int, error) {
+	var count int
+	var lastErr error
+	for {
+		account := bot.accounts.next()
+		if account == nil {
+			if bot.accounts.allExpired() {
+				return count, errSessionExpired
 			}
-		} else if tweet.ParsedTweet.IsRecommended || !tweet.ParsedTweet.ParsedUser.IsFollowing {
-			_, err := bot.processRetweet(&tweet.ParsedTweet, "")
-			if err != nil {
-				bot.errCount++
-				log.Println("processRetweet recommended error", tweet.ParsedTweet.Url, err)
-				bot.tg.SendMessage(bot.ownerID, fmt.Sprintf("processRetweet recommended error %s", tweet.ParsedTweet.Url), nil)
-				time.Sleep(time.Minute)
-				continue
-			}
-		} else {
-			_, err := bot.processTweet(&tweet.ParsedTweet)
-			if err != nil {
-				bot.errCount++
-				log.Println("processTweet error", tweet.ParsedTweet.Url, err)
-				bot.tg.SendMessage(bot.ownerID, fmt.Sprintf("processTweet error %s", tweet.ParsedTweet.Url), nil)
-				time.Sleep(time.Minute)
-				continue
+			if lastErr != nil {
+				return count, fmt.Errorf("%w: %v", errAllAccountsBackedOff, lastErr)
 			}
+			return count, errAllAccountsBackedOff
 		}
+		cursor, err := bot.loadCursor(source.cursorName(account), source.sorted)
+		if err != nil {
+			return count, err
+		}
+		n, err := bot.processTimeline(account, cursor, source)
+		count += n
+		if err == nil {
+			return count, bot.saveCursor(cursor)
+		}
+		bot.state.setTimelineResult(err)
+		if bot.checkAuthError(account, err) {
+			continue
+		}
+		bot.errCount.Add(1)
+		log.Println(source.name, "error", account.name, err)
+		account.backoff(5 * time.Minute)
+		lastErr = err
 	}
-	if bot.errCount > 5 {
-		return -1, fmt.Errorf("TOO MUCH ERROR")
-	}
-	time.Sleep(5 * time.Second)
-	for tweet := range bot.twit.GetHomeLatestTimeline(context.Background(), 5*20) {
+}
+
+func (bot *bot) processTimeline(account *twitterAccount, cursor *timelineCursor, source *timelineSource) (int, error) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	timeline := source.fetch(ctx, account)
+	// the scraper keeps sending until it sees the cancellation
+	defer func() {
+		go func() {
+			for range timeline {
+			}
+		}()
+	}()
+
+	follow := source.follow && account == bot.accounts.curator
+	var count int
+	for tweet := range timeline {
 		if tweet.Error != nil {
-			bot.errCount++
-			log.Println("GetHomeLatestTimeline error", tweet.Error)
-			bot.tg.SendMessage(bot.ownerID, "GetHomeLatestTimeline error", nil)
-			time.Sleep(time.Minute)
+			return count, tweet.Error
+		}
+		bot.errCount.Store(0)
+		bot.state.setTimelineResult(nil)
+
+		seen, done := cursor.seen(&tweet.ParsedTweet, bot.timelineReevaluate)
+		if done {
 			break
 		}
-		bot.errCount = 0
 
-		if !isMedia(tweet.ParsedTweet) {
+		quoted := quotedMedia(&tweet.ParsedTweet)
+		if !isMedia(tweet.ParsedTweet) && quoted == nil {
 			continue
 		}
 
@@ -1154,46 +1197,40 @@ func (bot *bot) newLoop() (int, error) {
 			continue
 		}
 
-		if tweet.ParsedTweet.IsRetweet {
-			ok, err := bot.processRetweet(tweet.ParsedTweet.RetweetedTweet, tweet.ParsedTweet.ParsedUser.UserId)
-			if err != nil {
-				bot.errCount++
-				log.Println("processRetweet error", tweet.ParsedTweet.Url, err)
-				bot.tg.SendMessage(bot.ownerID, fmt.Sprintf("processRetweet error %s", tweet.ParsedTweet.Url), nil)
-				time.Sleep(time.Minute)
-				continue
-			}
-			if ok {
-				count++
-			}
+		// an earlier loop has looked this one up already, only go back to the
+		// database when it may have become popular since
+		if seen && !source.popularity.mayBePopular(&tweet.ParsedTweet) {
+			continue
+		}
+
+		var ok bool
+		var err error
+		kind := "processTweet"
+		if quoted != nil {
+			kind = "processRetweet quote"
+			ok, err = bot.processRetweet(quoted, tweet.ParsedTweet.ParsedUser.UserId, &tweet.ParsedTweet, source.popularity.forQuote(), follow)
+		} else if tweet.ParsedTweet.IsRetweet {
+			kind = "processRetweet"
+			ok, err = bot.processRetweet(tweet.ParsedTweet.RetweetedTweet, tweet.ParsedTweet.ParsedUser.UserId, nil, source.popularity, follow)
 		} else if tweet.ParsedTweet.IsRecommended || !tweet.ParsedTweet.ParsedUser.IsFollowing {
-			ok, err := bot.processRetweet(&tweet.ParsedTweet, "")
-			if err != nil {
-				bot.errCount++
-				log.Println("processRetweet recommended error", tweet.ParsedTweet.Url, err)
-				bot.tg.SendMessage(bot.ownerID, fmt.Sprintf("processRetweet recommended error %s", tweet.ParsedTweet.Url), nil)
-				time.Sleep(time.Minute)
-				continue
-			}
-			if ok {
-				count++
-			}
+			kind = "processRetweet recommended"
+			ok, err = bot.processRetweet(&tweet.ParsedTweet, "", nil, source.popularity, follow)
 		} else {
-			ok, err := bot.processTweet(&tweet.ParsedTweet)
-			if err != nil {
-				bot.errCount++
-				log.Println("processTweet error", tweet.ParsedTweet.Url, err)
-				bot.tg.SendMessage(bot.ownerID, fmt.Sprintf("processTweet error %s", tweet.ParsedTweet.Url), nil)
-				time.Sleep(time.Minute)
+			ok, err = bot.processTweet(&tweet.ParsedTweet, source.popularity)
+		}
+		if err != nil {
+			if bot.checkAuthError(bot.accounts.curator, err) {
 				continue
 			}
-			if ok {
-				count++
-			}
+			bot.errCount.Add(1)
+			log.Println(kind, "error", tweet.ParsedTweet.Url, err)
+			bot.tg.SendMessage(bot.ownerID, fmt.Sprintf("%s error %s", kind, tweet.ParsedTweet.Url), nil)
+			time.Sleep(time.Minute)
+			continue
+		}
+		if ok {
+			count++
 		}
-	}
-	if bot.errCount > 5 {
-		return -1, fmt.Errorf("TOO MUCH ERROR")
 	}
 	return count, nil
 }
@@ -1206,5 +1243,12 @@ func (bot *bot) cleanup() error {
 	if count > 0 {
 		log.Printf("Deleted %d old tweet(s)", count)
 	}
+	count, err = models.Posts(models.PostWhere.CreatedAt.LT(time.Now().Add(-90*24*time.Hour))).DeleteAll(context.Background(), bot.db)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		log.Printf("Deleted %d old post(s)", count)
+	}
 	return nil
 }
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+type breakerState int
+
+const (
+	breakerClosed breakerState = iota
+	breakerOpen
+	breakerHalfOpen
+)
+
+func (s breakerState) String() string {
+	switch s {
+	case breakerClosed:
+		return "closed"
+	case breakerOpen:
+		return "open"
+	case breakerHalfOpen:
+		return "half-open"
+	}
+	return "unknown"
+}
+
+// circuitBreaker stops the scrape loop after it fails, and lets a single trial
+// run through once the cool-down has passed. Every failed trial doubles the
+// cool-down up to maxCooldown.
+type circuitBreaker struct {
+	mu sync.Mutex
+
+	state    breakerState
+	cooldown time.Duration
+	retryAt  time.Time
+	lastErr  error
+
+	minCooldown time.Duration
+	maxCooldown time.Duration
+}
+
+func newCircuitBreaker(minCooldown, maxCooldown time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		minCooldown: minCooldown,
+		maxCooldown: maxCooldown,
+	}
+}
+
+// wait returns how long the caller has to wait before it is allowed to run.
+// An open breaker whose cool-down has passed moves to half-open.
+func (cb *circuitBreaker) wait() time.Duration {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	if cb.state != breakerOpen {
+		return 0
+	}
+	if d := time.Until(cb.retryAt); d > 0 {
+		return d
+	}
+	cb.state = breakerHalfOpen
+	return 0
+}
+
+// failure records a failed run and returns the cool-down before the next
+// trial. tripped is only true when a closed breaker has just opened.
+func (cb *circuitBreaker) failure(err error) (cooldown time.Duration, tripped bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	tripped = cb.state == breakerClosed
+	if tripped {
+		cb.cooldown = cb.minCooldown
+	} else {
+		cb.cooldown = min(cb.cooldown*2, cb.maxCooldown)
+	}
+	cb.state = breakerOpen
+	cb.retryAt = time.Now().Add(cb.cooldown)
+	cb.lastErr = err
+	return cb.cooldown, tripped
+}
+
+// success closes the breaker and reports whether it was open before.
+func (cb *circuitBreaker) success() (recovered bool) {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	recovered = cb.state != breakerClosed
+	cb.state = breakerClosed
+	cb.cooldown = 0
+	cb.lastErr = nil
+	return recovered
+}
+
+func (cb *circuitBreaker) String() string {
+	cb.mu.Lock()
+	defer cb.mu.Unlock()
+	switch cb.state {
+	case breakerOpen:
+		return fmt.Sprintf("open until %s (%s)", formatTime(cb.retryAt), cb.lastErr)
+	case breakerHalfOpen:
+		return fmt.Sprintf("half-open (%s)", cb.lastErr)
+	}
+	return cb.state.String()
+}
@@ -0,0 +1,313 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"text/template"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/JasonKhew96/twiscraper/entity"
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+const (
+	maxCaptionLength = 1024
+	maxMessageLength = 4096
+)
+
+var (
+	mentionRegex = regexp.MustCompile(`@([A-Za-z0-9_]{1,15})`)
+	hashtagRegex = regexp.MustCompile(`[#＃]([\p{L}\p{M}\p{N}_]+)`)
+	cashtagRegex = regexp.MustCompile(`(?:^|[^\p{L}\p{N}_$])\$([A-Za-z]{1,6})\b`)
+)
+
+// utf16Len is the length Telegram counts for a text.
+func utf16Len(s string) int {
+	n := 0
+	for _, r := range s {
+		n += utf16.RuneLen(r)
+	}
+	return n
+}
+
+// richText is a plain text with the entities Telegram should apply to it.
+// Entity offsets and lengths are in UTF-16 code units.
+type richText struct {
+	text     string
+	entities []gotgbot.MessageEntity
+}
+
+func (t richText) len() int {
+	return utf16Len(t.text)
+}
+
+func (t *richText) write(s string) {
+	t.text += s
+}
+
+func (t *richText) add(s string, e gotgbot.MessageEntity) {
+	e.Offset = int64(t.len())
+	e.Length = int64(utf16Len(s))
+	t.text += s
+	t.entities = append(t.entities, e)
+}
+
+func (t *richText) link(s, link string) {
+	t.add(s, gotgbot.MessageEntity{Type: "text_link", Url: link})
+}
+
+func (t *richText) url(s string) {
+	t.add(s, gotgbot.MessageEntity{Type: "url"})
+}
+
+func (t *richText) append(o richText) {
+	offset := int64(t.len())
+	for _, e := range o.entities {
+		e.Offset += offset
+		t.entities = append(t.entities, e)
+	}
+	t.text += o.text
+}
+
+// slice returns the text between the UTF-16 offsets, with the entities
+// clipped to it. An offset between the halves of a surrogate pair steps back
+// to the start of the pair, the way cut does.
+func (t richText) slice(from, to int) richText {
+	var out richText
+	start, end, n := len(t.text), len(t.text), 0
+	for i, r := range t.text {
+		size := utf16.RuneLen(r)
+		if start == len(t.text) && n+size > from {
+			start, from = i, n
+		}
+		if n+size > to {
+			end, to = i, n
+			break
+		}
+		n += size
+	}
+	out.text = t.text[start:end]
+	for _, e := range t.entities {
+		s, f := max(int(e.Offset), from), min(int(e.Offset+e.Length), to)
+		if s >= f {
+			continue
+		}
+		e.Offset = int64(s - from)
+		e.Length = int64(f - s)
+		out.entities = append(out.entities, e)
+	}
+	return out
+}
+
+// trim removes the surrounding white space.
+func (t richText) trim() richText {
+	leading := utf16Len(t.text) - utf16Len(strings.TrimLeftFunc(t.text, unicode.IsSpace))
+	trailing := utf16Len(t.text) - utf16Len(strings.TrimRightFunc(t.text, unicode.IsSpace))
+	return t.slice(leading, max(leading, t.len()-trailing))
+}
+
+// cut splits the text after at most n UTF-16 code units. It steps back to
+// the start of an entity rather than cutting through it, and to the end of
+// the last word when there is one close by.
+func (t richText) cut(n int) (richText, richText) {
+	if t.len() <= n {
+		return t, richText{}
+	}
+	if n <= 0 {
+		return richText{}, t
+	}
+	// never between the halves of a surrogate pair
+	cut := 0
+	for _, r := range t.text {
+		if cut+utf16.RuneLen(r) > n {
+			break
+		}
+		cut += utf16.RuneLen(r)
+	}
+	for _, e := range t.entities {
+		if int(e.Offset) < cut && cut < int(e.Offset+e.Length) && e.Offset > 0 {
+			cut = int(e.Offset)
+		}
+	}
+	head := t.slice(0, cut)
+	if i := strings.LastIndexFunc(head.text, unicode.IsSpace); i >= 0 {
+		if words := utf16Len(head.text[:i]); words > cut*3/4 {
+			cut = words
+		}
+	}
+	return t.slice(0, cut).trim(), t.slice(cut, t.len()).trim()
+}
+
+// split cuts the text into pieces of at most n UTF-16 code units, none of
+// them blank.
+func (t richText) split(n int) []richText {
+	var pieces []richText
+	t = t.trim()
+	for t.len() > 0 {
+		var piece richText
+		piece, t = t.cut(n)
+		if piece.len() == 0 {
+			// a single entity or word longer than n, cut through it
+			head := t.slice(0, n)
+			if head.len() == 0 {
+				// n is shorter than the first character
+				_, size := utf8.DecodeRuneInString(t.text)
+				head = t.slice(0, utf16Len(t.text[:size]))
+			}
+			piece, t = head.trim(), t.slice(head.len(), t.len()).trim()
+		}
+		pieces = append(pieces, piece)
+	}
+	return pieces
+}
+
+// displayUrl shortens a link the way twitter shows it.
+func displayUrl(link string) string {
+	s := strings.TrimPrefix(strings.TrimPrefix(link, "https://"), "http://")
+	s = strings.TrimPrefix(s, "www.")
+	if utf8.RuneCountInString(s) > 25 {
+		s = string([]rune(s)[:24]) + "…"
+	}
+	return s
+}
+
+// tweetText links the mentions, hashtags, cashtags and links in the text of
+// the tweet. Links are shown shortened.
+func tweetText(tweet *entity.ParsedTweet) richText {
+	text := tweet.FullText
+
+	type span struct {
+		start, end    int
+		display, link string
+	}
+	var spans []span
+	for _, m := range mentionRegex.FindAllStringSubmatchIndex(text, -1) {
+		name := text[m[2]:m[3]]
+		for _, mention := range tweet.Entities.UserMentions {
+			if strings.EqualFold(mention.ScreenName, name) {
+				spans = append(spans, span{m[0], m[1], "@" + name, "https://x.com/" + mention.ScreenName})
+				break
+			}
+		}
+	}
+	for _, m := range hashtagRegex.FindAllStringSubmatchIndex(text, -1) {
+		tag := text[m[2]:m[3]]
+		for _, hashtag := range tweet.Entities.Hashtags {
+			if hashtag == tag {
+				spans = append(spans, span{m[0], m[1], "#" + tag, "https://x.com/hashtag/" + url.QueryEscape(tag)})
+				break
+			}
+		}
+	}
+	for _, m := range cashtagRegex.FindAllStringSubmatchIndex(text, -1) {
+		symbol := text[m[2]:m[3]]
+		spans = append(spans, span{m[2] - 1, m[3], "$" + symbol, "https://x.com/search?q=" + url.QueryEscape("$"+symbol)})
+	}
+	for _, link := range tweet.Entities.Urls {
+		if link == "" {
+			continue
+		}
+		for from := 0; ; {
+			i := strings.Index(text[from:], link)
+			if i < 0 {
+				break
+			}
+			spans = append(spans, span{from + i, from + i + len(link), displayUrl(link), link})
+			from += i + len(link)
+		}
+	}
+	sort.SliceStable(spans, func(i, j int) bool {
+		return spans[i].start < spans[j].start
+	})
+
+	var t richText
+	pos := 0
+	for _, s := range spans {
+		// overlapping matches, like a hashtag inside a link
+		if s.start < pos {
+			continue
+		}
+		t.write(text[pos:s.start])
+		t.link(s.display, s.link)
+		pos = s.end
+	}
+	t.write(text[pos:])
+	return t
+}
+
+// tweet2Caption returns the caption for the post from the template and the
+// follow-up messages for the text and alt texts that do not fit into it.
+// footer goes at the end of the caption and is never cut.
+func tweet2Caption(tweet *entity.ParsedTweet, tmpl *template.Template, footer richText) (richText, []richText) {
+	fill, err := renderCaption(tmpl, tweet, len(tweet.Entities.Media))
+	if err != nil {
+		log.Println("caption template error", tmpl.Name(), err)
+		fill, _ = renderCaption(defaultCaptionTemplate, tweet, len(tweet.Entities.Media))
+	}
+
+	// the footer never takes more than half of the caption
+	if footer.len() > maxCaptionLength/2 {
+		footer, _ = footer.cut(maxCaptionLength / 2)
+	}
+
+	text := tweetText(tweet).trim()
+	caption, rest := fill(text), richText{}
+	if caption.len()+footer.len() > maxCaptionLength {
+		// only the text gives way, the rest of the template and the link to
+		// the tweet in it stay whole. The template may use the text more
+		// than once
+		frame := fill(richText{}).len()
+		uses := max(1, (caption.len()-frame)/max(1, text.len()))
+		n := (maxCaptionLength-frame-footer.len())/uses - 1
+		for {
+			var head richText
+			head, rest = text.cut(n)
+			if head.len() == 0 {
+				caption, rest = fill(richText{}), text
+				break
+			}
+			head.write("…")
+			caption = fill(head)
+			if caption.len()+footer.len() <= maxCaptionLength {
+				break
+			}
+			n -= (caption.len()+footer.len()-maxCaptionLength)/uses + 1
+		}
+	}
+	if caption.len()+footer.len() > maxCaptionLength {
+		// a template too long even without the text
+		caption, _ = caption.cut(maxCaptionLength - footer.len())
+	}
+	caption.append(footer)
+
+	var alts []string
+	for index, media := range tweet.Entities.Media {
+		var altText string
+		switch v := media.(type) {
+		case entity.ParsedMediaPhoto:
+			altText = v.AltText
+		case entity.ParsedMediaVideo:
+			altText = v.AltText
+		}
+		if altText != "" {
+			alts = append(alts, fmt.Sprintf("[%d] %s", index+1, altText))
+		}
+	}
+	// alt texts stay in the caption while they fit, after the first one that
+	// does not they all move to the follow-up
+	for rest.len() == 0 && len(alts) > 0 && caption.len()+2+utf16Len(alts[0]) <= maxCaptionLength {
+		caption.write("\n\n" + alts[0])
+		alts = alts[1:]
+	}
+
+	followUps := rest.split(maxMessageLength)
+	altText := richText{text: strings.Join(alts, "\n\n")}
+	followUps = append(followUps, altText.split(maxMessageLength)...)
+	return caption, followUps
+}
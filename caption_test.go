@@ -0,0 +1,223 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+)
+
+func bold(offset, length int64) gotgbot.MessageEntity {
+	return gotgbot.MessageEntity{Type: "bold", Offset: offset, Length: length}
+}
+
+func checkRichText(t *testing.T, name string, got, want richText) {
+	t.Helper()
+	if got.text != want.text {
+		t.Errorf("%s: text %q, want %q", name, got.text, want.text)
+	}
+	if !reflect.DeepEqual(got.entities, want.entities) {
+		t.Errorf("%s: entities %+v, want %+v", name, got.entities, want.entities)
+	}
+}
+
+func TestRichTextSlice(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     richText
+		from, to int
+		want     richText
+	}{
+		{
+			name: "ascii",
+			text: richText{text: "hello world"},
+			from: 6, to: 11,
+			want: richText{text: "world"},
+		},
+		{
+			name: "to inside a surrogate pair",
+			text: richText{text: "a😀b"},
+			from: 0, to: 2,
+			want: richText{text: "a"},
+		},
+		{
+			name: "from inside a surrogate pair",
+			text: richText{text: "a😀b"},
+			from: 2, to: 4,
+			want: richText{text: "😀b"},
+		},
+		{
+			name: "both inside the same surrogate pair",
+			text: richText{text: "a😀b"},
+			from: 2, to: 2,
+			want: richText{text: ""},
+		},
+		{
+			name: "entity straddling the end",
+			text: richText{text: "hello world", entities: []gotgbot.MessageEntity{bold(3, 5)}},
+			from: 0, to: 5,
+			want: richText{text: "hello", entities: []gotgbot.MessageEntity{bold(3, 2)}},
+		},
+		{
+			name: "entity straddling the start",
+			text: richText{text: "hello world", entities: []gotgbot.MessageEntity{bold(3, 5)}},
+			from: 6, to: 11,
+			want: richText{text: "world", entities: []gotgbot.MessageEntity{bold(0, 2)}},
+		},
+		{
+			name: "entity clipped at a snapped bound",
+			text: richText{text: "ab😀cd", entities: []gotgbot.MessageEntity{bold(2, 4)}},
+			from: 0, to: 3,
+			want: richText{text: "ab"},
+		},
+		{
+			name: "entity after an emoji",
+			text: richText{text: "😀 hi", entities: []gotgbot.MessageEntity{bold(3, 2)}},
+			from: 1, to: 5,
+			want: richText{text: "😀 hi", entities: []gotgbot.MessageEntity{bold(3, 2)}},
+		},
+	}
+	for _, tt := range tests {
+		checkRichText(t, tt.name, tt.text.slice(tt.from, tt.to), tt.want)
+	}
+}
+
+func TestRichTextCut(t *testing.T) {
+	tests := []struct {
+		name       string
+		text       richText
+		n          int
+		head, rest richText
+	}{
+		{
+			name: "fits",
+			text: richText{text: "short"},
+			n:    10,
+			head: richText{text: "short"},
+		},
+		{
+			name: "nothing fits",
+			text: richText{text: "short"},
+			n:    0,
+			rest: richText{text: "short"},
+		},
+		{
+			name: "end of the last word",
+			text: richText{text: "hello wonderful world"},
+			n:    18,
+			head: richText{text: "hello wonderful"},
+			rest: richText{text: "world"},
+		},
+		{
+			name: "before an emoji",
+			text: richText{text: "abc😀def"},
+			n:    4,
+			head: richText{text: "abc"},
+			rest: richText{text: "😀def"},
+		},
+		{
+			name: "before an entity",
+			text: richText{text: "see example.com now", entities: []gotgbot.MessageEntity{{Type: "url", Offset: 4, Length: 11}}},
+			n:    10,
+			head: richText{text: "see"},
+			rest: richText{text: "example.com now", entities: []gotgbot.MessageEntity{{Type: "url", Offset: 0, Length: 11}}},
+		},
+		{
+			name: "through an entity at the start",
+			text: richText{text: "example.com", entities: []gotgbot.MessageEntity{{Type: "url", Offset: 0, Length: 11}}},
+			n:    7,
+			head: richText{text: "example", entities: []gotgbot.MessageEntity{{Type: "url", Offset: 0, Length: 7}}},
+			rest: richText{text: ".com", entities: []gotgbot.MessageEntity{{Type: "url", Offset: 0, Length: 4}}},
+		},
+		{
+			name: "whitespace-only head",
+			text: richText{text: "      abcdefghij"},
+			n:    5,
+			rest: richText{text: "abcdefghij"},
+		},
+	}
+	for _, tt := range tests {
+		head, rest := tt.text.cut(tt.n)
+		checkRichText(t, tt.name+" head", head, tt.head)
+		checkRichText(t, tt.name+" rest", rest, tt.rest)
+	}
+}
+
+func TestRichTextSplit(t *testing.T) {
+	tests := []struct {
+		name string
+		text richText
+		n    int
+		want []string
+	}{
+		{
+			name: "words",
+			text: richText{text: "one two three"},
+			n:    9,
+			want: []string{"one two", "three"},
+		},
+		{
+			name: "emoji",
+			text: richText{text: "😀😀😀"},
+			n:    3,
+			want: []string{"😀", "😀", "😀"},
+		},
+		{
+			name: "emoji longer than n",
+			text: richText{text: "😀😀"},
+			n:    1,
+			want: []string{"😀", "😀"},
+		},
+		{
+			name: "whitespace-only head",
+			text: richText{text: "      abcdefghij"},
+			n:    5,
+			want: []string{"abcde", "fghij"},
+		},
+		{
+			name: "blank",
+			text: richText{text: " \n\t "},
+			n:    5,
+		},
+		{
+			name: "entity longer than n",
+			text: richText{text: "x https://example.com/abc", entities: []gotgbot.MessageEntity{{Type: "url", Offset: 2, Length: 23}}},
+			n:    10,
+			want: []string{"x", "https://ex", "ample.com/", "abc"},
+		},
+	}
+	for _, tt := range tests {
+		pieces := tt.text.split(tt.n)
+		var got []string
+		for _, piece := range pieces {
+			// a single character longer than n cannot be cut
+			if piece.len() > tt.n && utf8.RuneCountInString(piece.text) > 1 {
+				t.Errorf("%s: piece %q is longer than %d", tt.name, piece.text, tt.n)
+			}
+			for _, e := range piece.entities {
+				if e.Offset < 0 || int(e.Offset+e.Length) > piece.len() {
+					t.Errorf("%s: entity %+v is outside of %q", tt.name, e, piece.text)
+				}
+			}
+			got = append(got, piece.text)
+		}
+		if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	// the pieces of a link each keep their part of it
+	pieces := richText{text: "https://example.com/abc", entities: []gotgbot.MessageEntity{{Type: "url", Offset: 0, Length: 23}}}.split(10)
+	want := []richText{
+		{text: "https://ex", entities: []gotgbot.MessageEntity{{Type: "url", Offset: 0, Length: 10}}},
+		{text: "ample.com/", entities: []gotgbot.MessageEntity{{Type: "url", Offset: 0, Length: 10}}},
+		{text: "abc", entities: []gotgbot.MessageEntity{{Type: "url", Offset: 0, Length: 3}}},
+	}
+	if len(pieces) != len(want) {
+		t.Fatalf("link: %d pieces, want %d", len(pieces), len(want))
+	}
+	for i := range want {
+		checkRichText(t, "link", pieces[i], want[i])
+	}
+}
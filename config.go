@@ -1,16 +1,75 @@
 package main
 
 import (
+	"fmt"
 	"os"
+	"path/filepath"
 	"strconv"
+	"time"
 
 	"github.com/pkg/errors"
+	"github.com/spf13/viper"
 )
 
+type TwitterAccountConfig struct {
+	Name       string
+	Cookie     string
+	XCsrfToken string
+}
+
+// SourceConfig is a timeline read besides the home timelines. Target is the
+// list id, the search query or the screen name, depending on Type.
+type SourceConfig struct {
+	Name                 string        `mapstructure:"name"`
+	Type                 string        `mapstructure:"type"`
+	Target               string        `mapstructure:"target"`
+	Interval             time.Duration `mapstructure:"interval"`
+	Pages                int           `mapstructure:"pages"`
+	PopularTweetFactor   int           `mapstructure:"popular_tweet_factor"`
+	PopularRetweetFactor int           `mapstructure:"popular_retweet_factor"`
+	PopularQuoteFactor   int           `mapstructure:"popular_quote_factor"`
+	// whether illustrators found here are followed by the curator
+	Follow bool `mapstructure:"follow"`
+}
+
+// DestinationConfig is a channel tweets are routed to besides the main one.
+// The popularity factors are checked on top of the ones of the source, the
+// ones not set default to the global factors.
+type DestinationConfig struct {
+	Name                 string       `mapstructure:"name"`
+	ChannelChatID        int64        `mapstructure:"channel_chat_id"`
+	GroupChatID          int64        `mapstructure:"group_chat_id"`
+	CaptionTemplate      string       `mapstructure:"caption_template"`
+	PopularTweetFactor   int          `mapstructure:"popular_tweet_factor"`
+	PopularRetweetFactor int          `mapstructure:"popular_retweet_factor"`
+	PopularQuoteFactor   int          `mapstructure:"popular_quote_factor"`
+	Rules                []RuleConfig `mapstructure:"rules"`
+}
+
+// RuleConfig matches a tweet when everything that is set matches. Media is
+// any of photo, video and gif.
+type RuleConfig struct {
+	Authors   []string `mapstructure:"authors"`
+	Hashtags  []string `mapstructure:"hashtags"`
+	Text      string   `mapstructure:"text"`
+	Media     []string `mapstructure:"media"`
+	Sensitive *bool    `mapstructure:"sensitive"`
+}
+
+// SimilarityConfig weighs the metrics of two images into a score from 0 to
+// 1, Threshold is the score they count as the same image from.
+type SimilarityConfig struct {
+	Threshold float64 `mapstructure:"threshold"`
+	PHash     float64 `mapstructure:"phash"`
+	DHash     float64 `mapstructure:"dhash"`
+	AHash     float64 `mapstructure:"ahash"`
+	Histogram float64 `mapstructure:"histogram"`
+}
+
 type Config struct {
 	DatabaseUrl      string
-	TwitterCookie    string
-	XCsrfToken       string
+	TwitterAccounts  []TwitterAccountConfig
+	TwitterCurator   string
 	TelegramBotToken string
 
 	ChannelChatID int64
@@ -22,7 +81,35 @@ type Config struct {
 
 	PopularTweetFactor   int
 	PopularRetweetFactor int
-	BotApiUrl            string
+	PopularQuoteFactor   int
+	TimelineReevaluate   time.Duration
+	Sources              []SourceConfig
+	GraphQLQueryIds      map[string]string
+	CaptionTemplates     map[string]string
+	Destinations         []DestinationConfig
+
+	SensitiveMode     string
+	SensitiveKeywords []string
+	SensitiveHashtags []string
+	NsfwChannelChatID int64
+	NsfwGroupChatID   int64
+	BotApiUrl         string
+
+	DownloadLimit       int64
+	DownloadMemoryLimit int64
+	DownloadDir         string
+	MediaCacheSize      int64
+
+	DuplicateMode     string
+	DuplicateDistance int
+	Similarity        SimilarityConfig
+
+	ArchiveDir         string
+	ArchiveS3Endpoint  string
+	ArchiveS3Bucket    string
+	ArchiveS3Region    string
+	ArchiveS3AccessKey string
+	ArchiveS3SecretKey string
 }
 
 func loadConfig() (*Config, error) {
@@ -41,6 +128,28 @@ func loadConfig() (*Config, error) {
 		return nil, errors.New("XCSRF_TOKEN is not set")
 	}
 
+	twitterAccounts := []TwitterAccountConfig{{
+		Name:       "1",
+		Cookie:     twitterCookie,
+		XCsrfToken: xcsrfToken,
+	}}
+	for i := 2; os.Getenv(fmt.Sprintf("TWITTER_COOKIE_%d", i)) != ""; i++ {
+		token := os.Getenv(fmt.Sprintf("XCSRF_TOKEN_%d", i))
+		if token == "" {
+			return nil, errors.Errorf("XCSRF_TOKEN_%d is not set", i)
+		}
+		twitterAccounts = append(twitterAccounts, TwitterAccountConfig{
+			Name:       strconv.Itoa(i),
+			Cookie:     os.Getenv(fmt.Sprintf("TWITTER_COOKIE_%d", i)),
+			XCsrfToken: token,
+		})
+	}
+
+	twitterCurator := os.Getenv("TWITTER_CURATOR")
+	if twitterCurator == "" {
+		twitterCurator = "1"
+	}
+
 	telegramBotToken := os.Getenv("TELEGRAM_BOT_TOKEN")
 	if telegramBotToken == "" {
 		return nil, errors.New("TELEGRAM_BOT_TOKEN is not set")
@@ -99,12 +208,238 @@ func loadConfig() (*Config, error) {
 	if popularRetweetFactor == 0 || err != nil {
 		return nil, errors.Wrap(err, "POPULAR_RETWEET_FACTOR is not a number")
 	}
+
+	// quotes are judged like retweets unless told otherwise
+	popularQuoteFactor := popularRetweetFactor
+	if s := os.Getenv("POPULAR_QUOTE_FACTOR"); s != "" {
+		popularQuoteFactor, err = strconv.Atoi(s)
+		if popularQuoteFactor == 0 || err != nil {
+			return nil, errors.Wrap(err, "POPULAR_QUOTE_FACTOR is not a number")
+		}
+	}
+
+	timelineReevaluate := 3 * time.Hour
+	if s := os.Getenv("TIMELINE_REEVALUATE"); s != "" {
+		timelineReevaluate, err = time.ParseDuration(s)
+		if err != nil {
+			return nil, errors.Wrap(err, "TIMELINE_REEVALUATE is not a duration")
+		}
+	}
+
+	// settings that do not fit into environment variables
+	v := viper.New()
+	if configFile := os.Getenv("CONFIG_FILE"); configFile != "" {
+		v.SetConfigFile(configFile)
+		if err := v.ReadInConfig(); err != nil {
+			return nil, errors.Wrap(err, "failed to read CONFIG_FILE")
+		}
+	}
+
+	var sources []SourceConfig
+	if err := v.UnmarshalKey("sources", &sources); err != nil {
+		return nil, errors.Wrap(err, "sources is invalid")
+	}
+	names := make(map[string]bool)
+	for i := range sources {
+		source := &sources[i]
+		if source.Target == "" {
+			return nil, errors.Errorf("sources[%d].target is not set", i)
+		}
+		if source.Name == "" {
+			source.Name = source.Type + ":" + source.Target
+		}
+		// the name keys the timeline cursor
+		if names[source.Name] {
+			return nil, errors.Errorf("source %s is duplicated", source.Name)
+		}
+		names[source.Name] = true
+		if source.Interval <= 0 {
+			source.Interval = time.Hour
+		}
+		if source.Pages <= 0 {
+			source.Pages = 1
+		}
+		if source.PopularTweetFactor <= 0 {
+			source.PopularTweetFactor = popularTweetFactor
+		}
+		if source.PopularRetweetFactor <= 0 {
+			source.PopularRetweetFactor = popularRetweetFactor
+		}
+		if source.PopularQuoteFactor <= 0 {
+			source.PopularQuoteFactor = popularQuoteFactor
+		}
+	}
+
+	// the query ids rot as X updates its web client, see graphql.go
+	graphQLQueryIds := make(map[string]string)
+	for operation, queryId := range defaultGraphQLQueryIds {
+		graphQLQueryIds[operation] = queryId
+		if s := v.GetString("graphql_query_ids." + operation); s != "" {
+			graphQLQueryIds[operation] = s
+		}
+	}
+
+	// text/template captions keyed by chat id, or "default"
+	captionTemplates := v.GetStringMapString("caption_templates")
+	if captionTemplates == nil {
+		captionTemplates = make(map[string]string)
+	}
+
+	var destinations []DestinationConfig
+	if err := v.UnmarshalKey("destinations", &destinations); err != nil {
+		return nil, errors.Wrap(err, "destinations is invalid")
+	}
+	for i := range destinations {
+		destination := &destinations[i]
+		if destination.ChannelChatID == 0 {
+			return nil, errors.Errorf("destinations[%d].channel_chat_id is not set", i)
+		}
+		if destination.GroupChatID == 0 {
+			return nil, errors.Errorf("destinations[%d].group_chat_id is not set", i)
+		}
+		if destination.Name == "" {
+			destination.Name = strconv.FormatInt(destination.ChannelChatID, 10)
+		}
+		if destination.CaptionTemplate != "" {
+			captionTemplates[strconv.FormatInt(destination.ChannelChatID, 10)] = destination.CaptionTemplate
+		}
+		if destination.PopularTweetFactor > 0 || destination.PopularRetweetFactor > 0 || destination.PopularQuoteFactor > 0 {
+			if destination.PopularTweetFactor <= 0 {
+				destination.PopularTweetFactor = popularTweetFactor
+			}
+			if destination.PopularRetweetFactor <= 0 {
+				destination.PopularRetweetFactor = popularRetweetFactor
+			}
+			if destination.PopularQuoteFactor <= 0 {
+				destination.PopularQuoteFactor = popularQuoteFactor
+			}
+		}
+	}
+
+	v.SetDefault("similarity.threshold", 0.85)
+	v.SetDefault("similarity.phash", 0.35)
+	v.SetDefault("similarity.dhash", 0.3)
+	v.SetDefault("similarity.ahash", 0.15)
+	v.SetDefault("similarity.histogram", 0.2)
+	var similarity SimilarityConfig
+	if err := v.UnmarshalKey("similarity", &similarity); err != nil {
+		return nil, errors.Wrap(err, "similarity is invalid")
+	}
+	if similarity.Threshold < 0 || similarity.Threshold > 1 {
+		return nil, errors.Errorf("similarity.threshold %v is not between 0 and 1", similarity.Threshold)
+	}
+	if similarity.PHash < 0 || similarity.DHash < 0 || similarity.AHash < 0 || similarity.Histogram < 0 {
+		return nil, errors.New("similarity weights must not be negative")
+	}
+	if similarity.PHash+similarity.DHash+similarity.AHash+similarity.Histogram == 0 {
+		return nil, errors.New("similarity weights are all zero")
+	}
+
+	sensitiveMode := os.Getenv("SENSITIVE_MODE")
+	if sensitiveMode == "" {
+		sensitiveMode = sensitiveSpoiler
+	}
+	if sensitiveMode != sensitiveSpoiler && sensitiveMode != sensitiveChannel && sensitiveMode != sensitiveDrop {
+		return nil, errors.Errorf("SENSITIVE_MODE %s is not one of spoiler, channel or drop", sensitiveMode)
+	}
+	var nsfwChannelChatID, nsfwGroupChatID int64
+	if sensitiveMode == sensitiveChannel {
+		nsfwChannelChatID, err = strconv.ParseInt(os.Getenv("NSFW_CHANNEL_CHAT_ID"), 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "NSFW_CHANNEL_CHAT_ID is not a number")
+		}
+		if nsfwChannelChatID == 0 {
+			return nil, errors.Errorf("NSFW_CHANNEL_CHAT_ID %d is not a chat id", nsfwChannelChatID)
+		}
+		nsfwGroupChatID, err = strconv.ParseInt(os.Getenv("NSFW_GROUP_CHAT_ID"), 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "NSFW_GROUP_CHAT_ID is not a number")
+		}
+		if nsfwGroupChatID == 0 {
+			return nil, errors.Errorf("NSFW_GROUP_CHAT_ID %d is not a chat id", nsfwGroupChatID)
+		}
+	}
+
+	duplicateMode := os.Getenv("DUPLICATE_MODE")
+	if duplicateMode == "" {
+		duplicateMode = duplicateSkip
+	}
+	if duplicateMode != duplicateSkip && duplicateMode != duplicateFlag && duplicateMode != duplicateOff {
+		return nil, errors.Errorf("DUPLICATE_MODE %s is not one of skip, flag or off", duplicateMode)
+	}
+	// the most bits a pHash may differ in for two images to be scored at all,
+	// in the channels and in the Moe Island group
+	duplicateDistance := 5
+	if s := os.Getenv("DUPLICATE_DISTANCE"); s != "" {
+		duplicateDistance, err = strconv.Atoi(s)
+		if err != nil {
+			return nil, errors.Wrap(err, "DUPLICATE_DISTANCE is not a number")
+		}
+		if duplicateDistance < 0 {
+			return nil, errors.Errorf("DUPLICATE_DISTANCE %d is negative", duplicateDistance)
+		}
+	}
+
 	botApiUrl := os.Getenv("BOT_API_URL")
 
+	// the cloud Bot API takes uploads up to 50 MB, a local server up to 2 GB
+	downloadLimit := int64(50)
+	if botApiUrl != "" {
+		downloadLimit = 2000
+	}
+	if s := os.Getenv("DOWNLOAD_LIMIT_MB"); s != "" {
+		downloadLimit, err = strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "DOWNLOAD_LIMIT_MB is not a number")
+		}
+		if downloadLimit <= 0 {
+			return nil, errors.Errorf("DOWNLOAD_LIMIT_MB %d is not positive", downloadLimit)
+		}
+	}
+	downloadMemoryLimit := int64(10)
+	if s := os.Getenv("DOWNLOAD_MEMORY_LIMIT_MB"); s != "" {
+		downloadMemoryLimit, err = strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "DOWNLOAD_MEMORY_LIMIT_MB is not a number")
+		}
+		if downloadMemoryLimit < 0 {
+			return nil, errors.Errorf("DOWNLOAD_MEMORY_LIMIT_MB %d is negative", downloadMemoryLimit)
+		}
+	}
+	mediaCacheSize := int64(1024)
+	if s := os.Getenv("MEDIA_CACHE_SIZE_MB"); s != "" {
+		mediaCacheSize, err = strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "MEDIA_CACHE_SIZE_MB is not a number")
+		}
+		if mediaCacheSize <= 0 {
+			return nil, errors.Errorf("MEDIA_CACHE_SIZE_MB %d is not positive", mediaCacheSize)
+		}
+	}
+	// DOWNLOAD_DIR holds the media cache and its index
+	downloadDir := os.Getenv("DOWNLOAD_DIR")
+	if downloadDir == "" {
+		downloadDir = filepath.Join(os.TempDir(), "twitter-bot")
+	}
+
+	// originals are archived to the directory, or to the bucket when an S3
+	// endpoint is set
+	archiveS3Endpoint := os.Getenv("ARCHIVE_S3_ENDPOINT")
+	archiveS3Bucket := os.Getenv("ARCHIVE_S3_BUCKET")
+	archiveS3Region := os.Getenv("ARCHIVE_S3_REGION")
+	if archiveS3Endpoint != "" {
+		if archiveS3Bucket == "" {
+			return nil, errors.New("ARCHIVE_S3_BUCKET is not set")
+		}
+		if archiveS3Region == "" {
+			archiveS3Region = "us-east-1"
+		}
+	}
+
 	return &Config{
 		DatabaseUrl:          databaseUrl,
-		TwitterCookie:        twitterCookie,
-		XCsrfToken:           xcsrfToken,
+		TwitterAccounts:      twitterAccounts,
+		TwitterCurator:       twitterCurator,
 		TelegramBotToken:     telegramBotToken,
 		ChannelChatID:        channelChatID,
 		GroupChatID:          groupChatID,
@@ -113,6 +448,30 @@ func loadConfig() (*Config, error) {
 		MoeIslandGroupID:     moeIslandGroupID,
 		PopularTweetFactor:   popularTweetFactor,
 		PopularRetweetFactor: popularRetweetFactor,
+		PopularQuoteFactor:   popularQuoteFactor,
+		TimelineReevaluate:   timelineReevaluate,
+		Sources:              sources,
+		GraphQLQueryIds:      graphQLQueryIds,
+		CaptionTemplates:     captionTemplates,
+		Destinations:         destinations,
+		SensitiveMode:        sensitiveMode,
+		SensitiveKeywords:    v.GetStringSlice("sensitive_keywords"),
+		SensitiveHashtags:    v.GetStringSlice("sensitive_hashtags"),
+		DuplicateMode:        duplicateMode,
+		DuplicateDistance:    duplicateDistance,
+		Similarity:           similarity,
+		NsfwChannelChatID:    nsfwChannelChatID,
+		NsfwGroupChatID:      nsfwGroupChatID,
 		BotApiUrl:            botApiUrl,
+		DownloadLimit:        downloadLimit << 20,
+		DownloadMemoryLimit:  downloadMemoryLimit << 20,
+		DownloadDir:          downloadDir,
+		MediaCacheSize:       mediaCacheSize << 20,
+		ArchiveDir:           os.Getenv("ARCHIVE_DIR"),
+		ArchiveS3Endpoint:    archiveS3Endpoint,
+		ArchiveS3Bucket:      archiveS3Bucket,
+		ArchiveS3Region:      archiveS3Region,
+		ArchiveS3AccessKey:   os.Getenv("ARCHIVE_S3_ACCESS_KEY"),
+		ArchiveS3SecretKey:   os.Getenv("ARCHIVE_S3_SECRET_KEY"),
 	}, nil
 }
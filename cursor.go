@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"time"
+	"twitter-bot/models"
+
+	"github.com/JasonKhew96/twiscraper/entity"
+	"github.com/pkg/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// timelineCursor is the high-water mark of a timeline, the newest tweet id
+// that has been processed by an earlier loop.
+type timelineCursor struct {
+	name   string
+	mark   int64
+	newest int64
+
+	// stopAtMark is only safe for timelines sorted by time.
+	stopAtMark bool
+}
+
+func (bot *bot) loadCursor(name string, stopAtMark bool) (*timelineCursor, error) {
+	c := &timelineCursor{
+		name:       name,
+		stopAtMark: stopAtMark,
+	}
+	m, err := models.FindTimelineCursor(context.Background(), bot.db, name)
+	if errors.Is(err, sql.ErrNoRows) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	c.mark = m.TweetID
+	c.newest = m.TweetID
+	return c, nil
+}
+
+func (bot *bot) saveCursor(c *timelineCursor) error {
+	if c.newest <= c.mark {
+		return nil
+	}
+	m := models.TimelineCursor{
+		Name:    c.name,
+		TweetID: c.newest,
+	}
+	if err := m.Upsert(context.Background(), bot.db, true, []string{"name"}, boil.Whitelist("tweet_id", "updated_at"), boil.Infer()); err != nil {
+		return err
+	}
+	c.mark = c.newest
+	return nil
+}
+
+// seen records the tweet and reports whether an earlier loop got past it
+// already. done is true once the timeline has gone past the mark by more than
+// the re-evaluation window, so paging can stop.
+func (c *timelineCursor) seen(tweet *entity.ParsedTweet, reevaluate time.Duration) (seen, done bool) {
+	id, err := strconv.ParseInt(tweet.TweetId, 10, 64)
+	if err != nil {
+		return false, false
+	}
+	if id > c.newest {
+		c.newest = id
+	}
+	if id > c.mark {
+		return false, false
+	}
+	return true, c.stopAtMark && time.Since(tweet.CreatedAt) > reevaluate
+}
@@ -0,0 +1,290 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"image"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"twitter-bot/models"
+
+	"github.com/JasonKhew96/twiscraper/entity"
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/corona10/goimagehash"
+	"github.com/pkg/errors"
+	"github.com/volatiletech/null/v8"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+	"github.com/volatiletech/sqlboiler/v4/queries/qm"
+)
+
+// What happens to a tweet whose photos were all posted to the channel
+// before. The owner hears of every near-duplicate either way.
+const (
+	duplicateSkip = "skip"
+	duplicateFlag = "flag"
+	duplicateOff  = "off"
+)
+
+// postLink links to a message of a private channel or group.
+func postLink(chatID, messageID int64) string {
+	return fmt.Sprintf("https://t.me/c/%s/%d", strings.TrimPrefix(fmt.Sprintf("%d", chatID), "-100"), messageID)
+}
+
+func (bot *bot) insertImage(chatID, messageID int64, fp *fingerprint) error {
+	img := models.Image{
+		// the 64 bits as they are, the sign is of no matter to bit_count
+		Hash:      int64(fp.pHash.GetHash()),
+		DHash:     null.Int64From(int64(fp.dHash.GetHash())),
+		AHash:     null.Int64From(int64(fp.aHash.GetHash())),
+		Histogram: null.BytesFrom(fp.histogram),
+		ChatID:    chatID,
+		MessageID: messageID,
+	}
+	return img.Insert(context.Background(), bot.db, boil.Infer())
+}
+
+// imageFingerprint is the fingerprint kept in the row. Rows from before the
+// dHash, aHash and histogram were kept only have the pHash; the missing
+// metrics are left nil.
+func imageFingerprint(img *models.Image) *fingerprint {
+	fp := &fingerprint{
+		pHash: goimagehash.NewImageHash(uint64(img.Hash), goimagehash.PHash),
+	}
+	if img.DHash.Valid {
+		fp.dHash = goimagehash.NewImageHash(uint64(img.DHash.Int64), goimagehash.DHash)
+	}
+	if img.AHash.Valid {
+		fp.aHash = goimagehash.NewImageHash(uint64(img.AHash.Int64), goimagehash.AHash)
+	}
+	if img.Histogram.Valid {
+		fp.histogram = img.Histogram.Bytes
+	}
+	return fp
+}
+
+// findSimilarImages returns the images of the chat within maxDistance of the
+// pHash that score at least the threshold, the most similar first. Postgres
+// counts the differing bits itself, so every image within the distance is
+// found, whatever the distance.
+func (bot *bot) findSimilarImages(chatID int64, fp *fingerprint, maxDistance int) ([]HashImage, error) {
+	allImgs, err := models.Images(qm.Where("chat_id = ? AND bit_count((hash # ?)::bit(64)) <= ?", chatID, int64(fp.pHash.GetHash()), maxDistance)).All(context.Background(), bot.db)
+	if err != nil {
+		return nil, err
+	}
+
+	var images []HashImage
+	for _, img := range allImgs {
+		similarity := bot.similarity.compare(fp, imageFingerprint(img))
+		if similarity.score < bot.similarity.threshold {
+			continue
+		}
+		images = append(images, HashImage{
+			ChatID:     img.ChatID,
+			MessageID:  img.MessageID,
+			Similarity: similarity,
+		})
+	}
+	sort.Slice(images, func(i, j int) bool {
+		return images[i].Similarity.score > images[j].Similarity.score
+	})
+	return images, nil
+}
+
+// fingerprintPhotos returns the fingerprint of every photo of the tweet, at
+// the index of its media. Videos and photos that fail to download are left
+// nil.
+func (bot *bot) fingerprintPhotos(tweet *entity.ParsedTweet) []*fingerprint {
+	if bot.duplicateMode == duplicateOff {
+		return nil
+	}
+	fingerprints := make([]*fingerprint, len(tweet.Entities.Media))
+	for i, media := range tweet.Entities.Media {
+		if _, ok := media.(entity.ParsedMediaPhoto); !ok {
+			continue
+		}
+		// the same file is uploaded later, from the cache
+		file := mediaFileOf(media, tweet.TweetId, i+1, "large")
+		fp, err := bot.fingerprintUrl(file.url)
+		if err != nil {
+			log.Println("fingerprintPhotos error", file.url, err)
+			continue
+		}
+		fingerprints[i] = fp
+	}
+	return fingerprints
+}
+
+func (bot *bot) fingerprintUrl(url string) (*fingerprint, error) {
+	f, _, err := bot.fetcher.fetch(url)
+	if err != nil {
+		return nil, err
+	}
+	img, _, err := image.Decode(f)
+	f.Close()
+	if err != nil {
+		return nil, err
+	}
+	return newFingerprint(img)
+}
+
+// messageImage returns the file id of the image to fingerprint of the
+// message: the largest size of a photo, an image document, or else the
+// thumbnail of a document, video or animation.
+func messageImage(msg *gotgbot.Message) string {
+	switch {
+	case len(msg.Photo) > 0:
+		return msg.Photo[len(msg.Photo)-1].FileId
+	case msg.Document != nil && strings.HasPrefix(msg.Document.MimeType, "image/"):
+		return msg.Document.FileId
+	case msg.Document != nil && msg.Document.Thumbnail != nil:
+		return msg.Document.Thumbnail.FileId
+	case msg.Video != nil && msg.Video.Thumbnail != nil:
+		return msg.Video.Thumbnail.FileId
+	case msg.Animation != nil && msg.Animation.Thumbnail != nil:
+		return msg.Animation.Thumbnail.FileId
+	}
+	return ""
+}
+
+// duplicate is a photo of the tweet and the closest earlier post of it.
+type duplicate struct {
+	position int
+	match    HashImage
+}
+
+// findDuplicates looks up the photos in what the channel has posted. all
+// tells whether every photo was posted before.
+func (bot *bot) findDuplicates(chatID int64, fingerprints []*fingerprint) (duplicates []duplicate, all bool) {
+	photos := 0
+	for i, fp := range fingerprints {
+		if fp == nil {
+			continue
+		}
+		photos++
+		images, err := bot.findSimilarImages(chatID, fp, bot.duplicateDistance)
+		if err != nil {
+			log.Println("findSimilarImages error", err)
+			continue
+		}
+		if len(images) > 0 {
+			duplicates = append(duplicates, duplicate{position: i + 1, match: images[0]})
+		}
+	}
+	return duplicates, photos > 0 && len(duplicates) == photos
+}
+
+// notifyDuplicates tells the owner which earlier posts the photos of the
+// tweet match.
+func (bot *bot) notifyDuplicates(tweet *entity.ParsedTweet, d *destination, duplicates []duplicate, skipped bool) {
+	msg := fmt.Sprintf("Near-duplicate in %s:\n%s\n", d.name, tweet.Url)
+	for _, dup := range duplicates {
+		msg += fmt.Sprintf("#%d %s %s\n", dup.position, postLink(dup.match.ChatID, dup.match.MessageID), dup.match.Similarity)
+	}
+	if skipped {
+		msg += "Skipped"
+	} else {
+		msg += "Posted anyway"
+	}
+	if _, err := bot.tg.SendMessage(bot.ownerID, msg, nil); err != nil {
+		log.Println(err)
+	}
+}
+
+// insertImages keeps the fingerprints of the posted photos, so later reposts
+// of them are found.
+func (bot *bot) insertImages(chatID int64, groups []sentMediaGroup, fingerprints []*fingerprint) {
+	for _, group := range groups {
+		for j, index := range group.indexes {
+			if index >= len(fingerprints) || fingerprints[index] == nil || j >= len(group.messages) {
+				continue
+			}
+			if err := bot.insertImage(chatID, group.messages[j].MessageId, fingerprints[index]); err != nil {
+				log.Println("insertImage error", err)
+			}
+		}
+	}
+}
+
+// backfillImages fingerprints the photos of the posts not yet fingerprinted,
+// from before the channels were checked for duplicates. The photos are
+// fetched again from the urls the tweets table keeps, so only the first
+// tweet of a merged thread counts. Every photo of a post is kept under the
+// message id of the post. A post whose photos could not all be fetched is
+// tried again on the next run.
+func (bot *bot) backfillImages() (int, error) {
+	posts, err := models.Posts(models.PostWhere.Fingerprinted.EQ(false)).All(context.Background(), bot.db)
+	if err != nil {
+		return 0, err
+	}
+	count := 0
+	for _, post := range posts {
+		tweet, err := bot.getTweetById(post.TweetID)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return count, err
+		}
+		found, failed := false, false
+		if tweet != nil {
+			for i, link := range strings.Split(tweet.Medias, "|") {
+				if !strings.HasPrefix(link, "https://pbs.twimg.com/media/") {
+					continue
+				}
+				file := mediaFileOf(entity.ParsedMediaPhoto{Url: link}, strconv.FormatInt(post.TweetID, 10), i+1, "large")
+				fp, err := bot.fingerprintUrl(file.url)
+				if err != nil {
+					log.Println("backfillImages error", file.url, err)
+					failed = true
+					continue
+				}
+				if err := bot.insertImage(post.ChatID, post.MessageID, fp); err != nil {
+					return count, err
+				}
+				found = true
+			}
+		}
+		if found {
+			count++
+		}
+		if failed {
+			continue
+		}
+		post.Fingerprinted = true
+		post.UpdatedAt = time.Now()
+		if _, err := post.Update(context.Background(), bot.db, boil.Whitelist(models.PostColumns.Fingerprinted, models.PostColumns.UpdatedAt)); err != nil {
+			return count, err
+		}
+	}
+	return count, nil
+}
+
+// commandBackfill fingerprints the photos posted before duplicates were
+// looked for, in the background as it fetches every photo again.
+func (bot *bot) commandBackfill(b *gotgbot.Bot, ctx *ext.Context) error {
+	if ctx.EffectiveUser.Id != bot.ownerID {
+		return nil
+	}
+	if bot.duplicateMode == duplicateOff {
+		_, err := ctx.EffectiveMessage.Reply(b, "DUPLICATE_MODE is off.", nil)
+		return err
+	}
+	log.Println(ctx.EffectiveMessage.Text)
+
+	go func() {
+		count, err := bot.backfillImages()
+		msg := fmt.Sprintf("Fingerprinted the photos of %d earlier post(s)", count)
+		if err != nil {
+			log.Println("backfillImages error", err)
+			msg += "\n\n" + err.Error()
+		}
+		if _, err := bot.tg.SendMessage(bot.ownerID, msg, nil); err != nil {
+			log.Println(err)
+		}
+	}()
+
+	_, err := ctx.EffectiveMessage.Reply(b, "Fingerprinting the photos of earlier posts.", nil)
+	return err
+}
@@ -0,0 +1,248 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/pkg/errors"
+)
+
+type FileTooLargeError struct{}
+
+func (e *FileTooLargeError) Error() string {
+	return "file too large"
+}
+
+type mediaFile struct {
+	url       string
+	name      string
+	normalize bool
+	// where a video comes from, to step it down when it is too large
+	tweetId  string
+	tweetUrl string
+	thumbUrl string
+}
+
+// Nothing above limit is read, whatever the server claims the size is.
+type fetcher struct {
+	client      *http.Client
+	limit       int64
+	memoryLimit int64
+	cache       *mediaCache
+
+	mu       sync.Mutex
+	inflight map[string]*fetchCall
+}
+
+type fetchCall struct {
+	done chan struct{}
+	err  error
+}
+
+func newFetcher(limit, memoryLimit int64, cache *mediaCache) *fetcher {
+	return &fetcher{
+		client: &http.Client{
+			// large videos take a while, a server that does not answer does not
+			Timeout: 10 * time.Minute,
+			Transport: &http.Transport{
+				Proxy:                 http.ProxyFromEnvironment,
+				MaxIdleConnsPerHost:   fetchConcurrency,
+				ResponseHeaderTimeout: 15 * time.Second,
+			},
+		},
+		limit:       limit,
+		memoryLimit: memoryLimit,
+		cache:       cache,
+		inflight:    make(map[string]*fetchCall),
+	}
+}
+
+const fetchConcurrency = 4
+
+func (f *fetcher) inputFiles(files []mediaFile) []gotgbot.InputFileOrString {
+	inputFiles := make([]gotgbot.InputFileOrString, len(files))
+	sem := make(chan struct{}, fetchConcurrency)
+	var wg sync.WaitGroup
+	for i, file := range files {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			sem <- struct{}{}
+			defer func() { <-sem }()
+			inputFile, err := f.open(file)
+			if err != nil {
+				log.Println(err)
+				inputFiles[i] = gotgbot.InputFileByURL(file.url)
+				return
+			}
+			inputFiles[i] = inputFile
+		}()
+	}
+	wg.Wait()
+	return inputFiles
+}
+
+func (f *fetcher) open(file mediaFile) (gotgbot.InputFile, error) {
+	var cached *os.File
+	var size int64
+	var err error
+	if file.normalize {
+		cached, size, err = f.openNormalized(file)
+		file.name = strings.TrimSuffix(file.name, filepath.Ext(file.name)) + ".jpg"
+	} else {
+		cached, size, err = f.fetch(file.url)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if size > f.memoryLimit {
+		return gotgbot.InputFileByReader(file.name, &closeOnEOF{f: cached}), nil
+	}
+	defer cached.Close()
+	buf := new(bytes.Buffer)
+	if _, err := buf.ReadFrom(cached); err != nil {
+		return nil, errors.Wrap(err, "failed to read cached file")
+	}
+	return gotgbot.InputFileByReader(file.name, buf), nil
+}
+
+func (f *fetcher) fetch(url string) (*os.File, int64, error) {
+	if cached, size, ok := f.cache.open(url); ok {
+		return cached, size, nil
+	}
+
+	f.mu.Lock()
+	call, ok := f.inflight[url]
+	if !ok {
+		call = &fetchCall{done: make(chan struct{})}
+		f.inflight[url] = call
+		f.mu.Unlock()
+		call.err = f.download(url)
+		f.mu.Lock()
+		delete(f.inflight, url)
+		f.mu.Unlock()
+		close(call.done)
+	} else {
+		f.mu.Unlock()
+		<-call.done
+	}
+	if call.err != nil {
+		return nil, 0, call.err
+	}
+
+	cached, size, ok := f.cache.open(url)
+	if !ok {
+		return nil, 0, errors.Errorf("%s is gone from the cache", url)
+	}
+	return cached, size, nil
+}
+
+func (f *fetcher) download(url string) error {
+	var err error
+	for attempt := 0; attempt < 3; attempt++ {
+		if attempt > 0 {
+			time.Sleep(time.Duration(attempt) * 2 * time.Second)
+		}
+		var retry bool
+		retry, err = f.downloadOnce(url)
+		if err == nil || !retry {
+			return err
+		}
+	}
+	return err
+}
+
+func (f *fetcher) downloadOnce(url string) (bool, error) {
+	resp, err := f.client.Get(url)
+	if err != nil {
+		return true, errors.Wrap(err, "failed to download file")
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		retry := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+		return retry, fmt.Errorf("failed to download file %s: %s", url, resp.Status)
+	}
+
+	if resp.ContentLength > f.limit {
+		return false, &FileTooLargeError{}
+	}
+
+	tmp, err := f.cache.tempFile()
+	if err != nil {
+		return false, errors.Wrap(err, "failed to create temp file")
+	}
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(tmp, h), io.LimitReader(resp.Body, f.limit+1))
+	tmp.Close()
+	if err != nil {
+		os.Remove(tmp.Name())
+		return true, errors.Wrap(err, "failed to read file")
+	}
+	if n > f.limit {
+		os.Remove(tmp.Name())
+		return false, &FileTooLargeError{}
+	}
+	if err := f.cache.put(url, tmp.Name(), hex.EncodeToString(h.Sum(nil)), n); err != nil {
+		os.Remove(tmp.Name())
+		return false, errors.Wrap(err, "failed to cache file")
+	}
+	return false, nil
+}
+
+type closeOnEOF struct {
+	f      *os.File
+	closed bool
+}
+
+func (c *closeOnEOF) Read(p []byte) (int, error) {
+	if c.closed {
+		return 0, io.EOF
+	}
+	n, err := c.f.Read(p)
+	if err == io.EOF {
+		c.Close()
+	}
+	return n, err
+}
+
+func (c *closeOnEOF) Close() error {
+	if c.closed {
+		return nil
+	}
+	c.closed = true
+	return c.f.Close()
+}
+
+// closeInputMedias closes the files a failed upload did not read.
+func closeInputMedias(inputMedias []gotgbot.InputMedia) {
+	for _, inputMedia := range inputMedias {
+		var media gotgbot.InputFileOrString
+		switch m := inputMedia.(type) {
+		case gotgbot.InputMediaPhoto:
+			media = m.Media
+		case gotgbot.InputMediaVideo:
+			media = m.Media
+		case gotgbot.InputMediaAnimation:
+			media = m.Media
+		case gotgbot.InputMediaDocument:
+			media = m.Media
+		}
+		if f, ok := media.(*gotgbot.FileReader); ok {
+			if c, ok := f.Data.(io.Closer); ok {
+				c.Close()
+			}
+		}
+	}
+}
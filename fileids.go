@@ -0,0 +1,143 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"log"
+	"time"
+	"twitter-bot/models"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/pkg/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// mediaVariant is what the media is sent as. A file id only works for the
+// kind of message it came from.
+func mediaVariant(inputMedia gotgbot.InputMedia) string {
+	switch inputMedia.(type) {
+	case gotgbot.InputMediaPhoto:
+		return "photo"
+	case gotgbot.InputMediaVideo:
+		return "video"
+	case gotgbot.InputMediaAnimation:
+		return "animation"
+	case gotgbot.InputMediaDocument:
+		return "document"
+	}
+	return ""
+}
+
+// withMedia returns a copy of the input media with the file set.
+func withMedia(inputMedia gotgbot.InputMedia, media gotgbot.InputFileOrString) gotgbot.InputMedia {
+	switch m := inputMedia.(type) {
+	case gotgbot.InputMediaPhoto:
+		m.Media = media
+		return m
+	case gotgbot.InputMediaVideo:
+		m.Media = media
+		return m
+	case gotgbot.InputMediaAnimation:
+		m.Media = media
+		return m
+	case gotgbot.InputMediaDocument:
+		m.Media = media
+		return m
+	}
+	return inputMedia
+}
+
+// messageFileID returns the file id Telegram gave the media of the message.
+func messageFileID(msg gotgbot.Message, variant string) string {
+	switch variant {
+	case "photo":
+		if len(msg.Photo) > 0 {
+			return msg.Photo[len(msg.Photo)-1].FileId
+		}
+	case "video":
+		if msg.Video != nil {
+			return msg.Video.FileId
+		}
+	case "animation":
+		if msg.Animation != nil {
+			return msg.Animation.FileId
+		}
+	case "document":
+		if msg.Document != nil {
+			return msg.Document.FileId
+		}
+	}
+	return ""
+}
+
+func (bot *bot) getFileID(url, variant string) (string, error) {
+	f, err := models.FileIDs(models.FileIDWhere.URL.EQ(url), models.FileIDWhere.Variant.EQ(variant)).One(context.Background(), bot.db)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return f.FileID, nil
+}
+
+func (bot *bot) saveFileID(url, variant, fileID string) error {
+	f := models.FileID{
+		URL:       url,
+		Variant:   variant,
+		FileID:    fileID,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+	return f.Upsert(context.Background(), bot.db, true, []string{"url", "variant"}, boil.Whitelist("file_id", "updated_at"), boil.Infer())
+}
+
+// attachFiles sets the file of every input media, by the file id Telegram
+// gave it before when lookup is set, or else by uploading it. byID tells
+// whether any went by file id.
+func (bot *bot) attachFiles(inputMedias []gotgbot.InputMedia, files []mediaFile, lookup bool) (attached []gotgbot.InputMedia, byID bool) {
+	attached = make([]gotgbot.InputMedia, len(inputMedias))
+	var upload []int
+	for i, inputMedia := range inputMedias {
+		if lookup {
+			fileID, err := bot.getFileID(files[i].url, mediaVariant(inputMedia))
+			if err != nil {
+				log.Println("getFileID error", files[i].url, err)
+			}
+			if fileID != "" {
+				attached[i] = withMedia(inputMedia, gotgbot.InputFileByID(fileID))
+				byID = true
+				continue
+			}
+		}
+		upload = append(upload, i)
+	}
+
+	uploadFiles := make([]mediaFile, len(upload))
+	for j, i := range upload {
+		uploadFiles[j] = files[i]
+	}
+	inputFiles := bot.fetcher.inputFiles(uploadFiles)
+	for j, i := range upload {
+		attached[i] = withMedia(inputMedias[i], inputFiles[j])
+	}
+	return attached, byID
+}
+
+// saveFileIDs keeps the file ids of the sent messages for the next time the
+// same media is sent.
+func (bot *bot) saveFileIDs(inputMedias []gotgbot.InputMedia, files []mediaFile, msgs []gotgbot.Message) {
+	for i, msg := range msgs {
+		if i >= len(inputMedias) {
+			break
+		}
+		variant := mediaVariant(inputMedias[i])
+		fileID := messageFileID(msg, variant)
+		if fileID == "" {
+			continue
+		}
+		if err := bot.saveFileID(files[i].url, variant, fileID); err != nil {
+			log.Println("saveFileID error", files[i].url, err)
+		}
+	}
+}
@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+	"image"
+	// the formats images are decoded from, besides the JPEG and PNG of
+	// photo.go
+	_ "image/gif"
+	"math"
+
+	"github.com/corona10/goimagehash"
+	"github.com/pkg/errors"
+	_ "golang.org/x/image/bmp"
+	_ "golang.org/x/image/tiff"
+	_ "golang.org/x/image/webp"
+)
+
+// histogramLevels is how many levels of each of red, green and blue the
+// colour histogram tells apart.
+const histogramLevels = 4
+
+// fingerprint is what the images table keeps of an image. pHash finds the
+// candidates, dHash, aHash and the colour histogram tell apart the ones that
+// only share a layout, like the pages of a comic.
+type fingerprint struct {
+	pHash *goimagehash.ImageHash
+	dHash *goimagehash.ImageHash
+	aHash *goimagehash.ImageHash
+	// share of the pixels in each colour bin, out of 255
+	histogram []byte
+}
+
+func newFingerprint(img image.Image) (*fingerprint, error) {
+	pHash, err := goimagehash.PerceptionHash(img)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compute pHash")
+	}
+	dHash, err := goimagehash.DifferenceHash(img)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compute dHash")
+	}
+	aHash, err := goimagehash.AverageHash(img)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to compute aHash")
+	}
+	return &fingerprint{
+		pHash:     pHash,
+		dHash:     dHash,
+		aHash:     aHash,
+		histogram: colorHistogram(img),
+	}, nil
+}
+
+// colorHistogram counts the pixels in histogramLevels³ colour bins. Large
+// images are sampled, the shares barely move.
+func colorHistogram(img image.Image) []byte {
+	b := img.Bounds()
+	step := max(1, max(b.Dx(), b.Dy())/256)
+	var counts [histogramLevels * histogramLevels * histogramLevels]int
+	total := 0
+	for y := b.Min.Y; y < b.Max.Y; y += step {
+		for x := b.Min.X; x < b.Max.X; x += step {
+			// 16-bit channels down to the 2 bits of histogramLevels
+			r, g, bl, _ := img.At(x, y).RGBA()
+			bin := int(r>>14)*histogramLevels*histogramLevels + int(g>>14)*histogramLevels + int(bl>>14)
+			counts[bin]++
+			total++
+		}
+	}
+	histogram := make([]byte, len(counts))
+	if total == 0 {
+		return histogram
+	}
+	for i, count := range counts {
+		histogram[i] = byte(math.Round(float64(count) * 255 / float64(total)))
+	}
+	return histogram
+}
+
+// histogramSimilarity is the share of the two histograms that overlaps, from
+// 0 to 1.
+func histogramSimilarity(a, b []byte) float64 {
+	if len(a) != len(b) {
+		return 0
+	}
+	var overlap, sumA, sumB int
+	for i := range a {
+		overlap += int(min(a[i], b[i]))
+		sumA += int(a[i])
+		sumB += int(b[i])
+	}
+	if max(sumA, sumB) == 0 {
+		return 0
+	}
+	return float64(overlap) / float64(max(sumA, sumB))
+}
+
+// similarityWeights weighs the metrics into one score from 0 to 1. Images
+// scoring below threshold are not similar, however close their pHash is.
+type similarityWeights struct {
+	threshold float64
+	pHash     float64
+	dHash     float64
+	aHash     float64
+	histogram float64
+}
+
+func newSimilarityWeights(config SimilarityConfig) similarityWeights {
+	return similarityWeights{
+		threshold: config.Threshold,
+		pHash:     config.PHash,
+		dHash:     config.DHash,
+		aHash:     config.AHash,
+		histogram: config.Histogram,
+	}
+}
+
+// similarity is how alike two images are by every metric. A metric the
+// older rows do not have is -1 and left out of the score.
+type similarity struct {
+	pHash     int
+	dHash     int
+	aHash     int
+	histogram float64
+	score     float64
+}
+
+func (s similarity) String() string {
+	str := fmt.Sprintf("score %.2f, pHash %d", s.score, s.pHash)
+	if s.dHash >= 0 {
+		str += fmt.Sprintf(", dHash %d", s.dHash)
+	}
+	if s.aHash >= 0 {
+		str += fmt.Sprintf(", aHash %d", s.aHash)
+	}
+	if s.histogram >= 0 {
+		str += fmt.Sprintf(", histogram %.2f", s.histogram)
+	}
+	return str
+}
+
+// compare scores the two fingerprints, the weights of the missing metrics
+// going to the others.
+func (w similarityWeights) compare(a, b *fingerprint) similarity {
+	s := similarity{pHash: -1, dHash: -1, aHash: -1, histogram: -1}
+	var score, weights float64
+	hashes := []struct {
+		a, b     *goimagehash.ImageHash
+		weight   float64
+		distance *int
+	}{
+		{a.pHash, b.pHash, w.pHash, &s.pHash},
+		{a.dHash, b.dHash, w.dHash, &s.dHash},
+		{a.aHash, b.aHash, w.aHash, &s.aHash},
+	}
+	for _, h := range hashes {
+		if h.a == nil || h.b == nil {
+			continue
+		}
+		distance, err := h.a.Distance(h.b)
+		if err != nil {
+			continue
+		}
+		*h.distance = distance
+		score += h.weight * (1 - float64(distance)/64)
+		weights += h.weight
+	}
+	if a.histogram != nil && b.histogram != nil {
+		s.histogram = histogramSimilarity(a.histogram, b.histogram)
+		score += w.histogram * s.histogram
+		weights += w.histogram
+	}
+	if weights > 0 {
+		s.score = score / weights
+	}
+	return s
+}
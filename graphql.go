@@ -0,0 +1,353 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"time"
+
+	"github.com/JasonKhew96/twiscraper"
+	"github.com/JasonKhew96/twiscraper/entity"
+	"github.com/pkg/errors"
+)
+
+// twiscraper only knows the home and user timelines, lists and searches are
+// requested here the same way the web client does and parsed with its
+// entities. The bearer token and the user agent are twiscraper's, which does
+// not export them.
+const (
+	twitterBearerToken = "AAAAAAAAAAAAAAAAAAAAANRILgAAAAAAnNwIzUejRCOuH5E6I8xnZz4puTs%3D1Zv7ttfk8LF81IUq16cHjhLTvJu4FA33AGWWjCpTnA"
+	twitterUserAgent   = "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/108.0.0.0 Safari/537.36"
+
+	graphqlPageSize = 20
+	graphqlDelay    = 3 * time.Second
+)
+
+// The GraphQL operations requested here.
+const (
+	opListLatestTweetsTimeline = "ListLatestTweetsTimeline"
+	opSearchTimeline           = "SearchTimeline"
+	opTweetResultByRestId      = "TweetResultByRestId"
+)
+
+// defaultGraphQLQueryIds are the query ids of the operations as the web
+// client had them when they were last looked up. They rot: X changes them
+// with its web client, and the old ones then fail with 404 Not Found. The
+// current ones are in the main.*.js bundle of x.com and are set with
+// graphql_query_ids in CONFIG_FILE, no rebuild needed.
+var defaultGraphQLQueryIds = map[string]string{
+	opListLatestTweetsTimeline: "HjsWc-nwwHKYwHenbHm-tw",
+	opSearchTimeline:           "UN1i3zUiCWa-6r-Uaho4fw",
+	opTweetResultByRestId:      "Xl5pC_lBk_gcO2ItU39DQw",
+}
+
+var graphqlClient = &http.Client{Timeout: time.Minute}
+
+type timeline struct {
+	Instructions []entity.TimelineInstruction `json:"instructions"`
+}
+
+type listTimelineResponse struct {
+	Errors []entity.TwitterError `json:"errors"`
+	Data   struct {
+		List struct {
+			TweetsTimeline struct {
+				Timeline timeline `json:"timeline"`
+			} `json:"tweets_timeline"`
+		} `json:"list"`
+	} `json:"data"`
+}
+
+type searchTimelineResponse struct {
+	Errors []entity.TwitterError `json:"errors"`
+	Data   struct {
+		SearchByRawQuery struct {
+			SearchTimeline struct {
+				Timeline timeline `json:"timeline"`
+			} `json:"search_timeline"`
+		} `json:"search_by_raw_query"`
+	} `json:"data"`
+}
+
+type tweetResultResponse struct {
+	Errors []entity.TwitterError `json:"errors"`
+	Data   struct {
+		TweetResult entity.TweetResults `json:"tweetResult"`
+	} `json:"data"`
+}
+
+type tweetResultVariables struct {
+	TweetId                string `json:"tweetId"`
+	WithCommunity          bool   `json:"withCommunity"`
+	IncludePromotedContent bool   `json:"includePromotedContent"`
+	WithVoice              bool   `json:"withVoice"`
+}
+
+type listTimelineVariables struct {
+	ListId string `json:"listId"`
+	Count  int    `json:"count"`
+	Cursor string `json:"cursor,omitempty"`
+}
+
+type searchTimelineVariables struct {
+	RawQuery    string `json:"rawQuery"`
+	Count       int    `json:"count"`
+	Cursor      string `json:"cursor,omitempty"`
+	QuerySource string `json:"querySource"`
+	Product     string `json:"product"`
+}
+
+// getListTimeline streams the tweets of a list, newest first.
+func (a *twitterAccount) getListTimeline(ctx context.Context, listId string, count int) <-chan *twiscraper.TimelineResult {
+	return a.timelineStream(ctx, count, func(cursor string) (timeline, error) {
+		var resp listTimelineResponse
+		err := a.requestGraphQL(ctx, opListLatestTweetsTimeline, listTimelineVariables{
+			ListId: listId,
+			Count:  graphqlPageSize,
+			Cursor: cursor,
+		}, &resp)
+		if err == nil && len(resp.Errors) > 0 && len(resp.Data.List.TweetsTimeline.Timeline.Instructions) == 0 {
+			err = errors.New(resp.Errors[0].Message)
+		}
+		return resp.Data.List.TweetsTimeline.Timeline, err
+	})
+}
+
+// getSearchTimeline streams the latest tweets matching a search query.
+func (a *twitterAccount) getSearchTimeline(ctx context.Context, query string, count int) <-chan *twiscraper.TimelineResult {
+	return a.timelineStream(ctx, count, func(cursor string) (timeline, error) {
+		var resp searchTimelineResponse
+		err := a.requestGraphQL(ctx, opSearchTimeline, searchTimelineVariables{
+			RawQuery:    query,
+			Count:       graphqlPageSize,
+			Cursor:      cursor,
+			QuerySource: "typed_query",
+			Product:     "Latest",
+		}, &resp)
+		if err == nil && len(resp.Errors) > 0 && len(resp.Data.SearchByRawQuery.SearchTimeline.Timeline.Instructions) == 0 {
+			err = errors.New(resp.Errors[0].Message)
+		}
+		return resp.Data.SearchByRawQuery.SearchTimeline.Timeline, err
+	})
+}
+
+// getVideoVariants returns the mp4 urls of every video of the tweet, best
+// first. twiscraper only keeps the best one.
+func (a *twitterAccount) getVideoVariants(ctx context.Context, tweetId string) ([][]string, error) {
+	var resp tweetResultResponse
+	if err := a.requestGraphQL(ctx, opTweetResultByRestId, tweetResultVariables{
+		TweetId: tweetId,
+	}, &resp); err != nil {
+		return nil, err
+	}
+	result := resp.Data.TweetResult.Result
+	if result.Tweet != nil {
+		// TweetWithVisibilityResults
+		result = *result.Tweet
+	}
+	if result.RestId == "" {
+		if len(resp.Errors) > 0 {
+			return nil, errors.New(resp.Errors[0].Message)
+		}
+		return nil, errors.Errorf("tweet %s not found", tweetId)
+	}
+
+	var variants [][]string
+	for _, mediaRaw := range result.Legacy.ExtendedEntities.Media {
+		var media entity.MediaVideo
+		if err := json.Unmarshal(mediaRaw, &media); err != nil || (media.Type != "video" && media.Type != "animated_gif") {
+			continue
+		}
+		mp4 := media.VideoInfo.Variants[:0:0]
+		for _, variant := range media.VideoInfo.Variants {
+			if variant.ContentType == "video/mp4" {
+				mp4 = append(mp4, variant)
+			}
+		}
+		if len(mp4) == 0 {
+			continue
+		}
+		sort.SliceStable(mp4, func(i, j int) bool {
+			return mp4[i].Bitrate > mp4[j].Bitrate
+		})
+		var urls []string
+		for _, variant := range mp4 {
+			urls = append(urls, variant.Url)
+		}
+		variants = append(variants, urls)
+	}
+	return variants, nil
+}
+
+func (a *twitterAccount) timelineStream(ctx context.Context, count int, fetchPage func(cursor string) (timeline, error)) <-chan *twiscraper.TimelineResult {
+	ch := make(chan *twiscraper.TimelineResult)
+	go func() {
+		defer close(ch)
+		send := func(result *twiscraper.TimelineResult) bool {
+			select {
+			case ch <- result:
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		var cursor string
+		for sent := 0; sent < count; {
+			if cursor != "" {
+				select {
+				case <-time.After(graphqlDelay):
+				case <-ctx.Done():
+					return
+				}
+			}
+			page, err := fetchPage(cursor)
+			if err != nil {
+				send(&twiscraper.TimelineResult{Error: err})
+				return
+			}
+			tweets, next := parseTimeline(page)
+			if len(tweets) == 0 {
+				return
+			}
+			for _, tweet := range tweets {
+				if sent >= count || !send(&twiscraper.TimelineResult{ParsedTweet: tweet}) {
+					return
+				}
+				sent++
+			}
+			if next == "" || next == cursor {
+				return
+			}
+			cursor = next
+		}
+	}()
+	return ch
+}
+
+func (a *twitterAccount) requestGraphQL(ctx context.Context, operation string, variables interface{}, target interface{}) error {
+	queryId := a.queryIds[operation]
+	if queryId == "" {
+		return errors.Errorf("graphql operation %s has no query id", operation)
+	}
+	variablesJson, err := json.Marshal(variables)
+	if err != nil {
+		return err
+	}
+	featuresJson, err := json.Marshal(entity.NewUserTweetsFeatures())
+	if err != nil {
+		return err
+	}
+	u, err := url.Parse("https://x.com/i/api/graphql/" + queryId + "/" + operation)
+	if err != nil {
+		return err
+	}
+	u.RawQuery = url.Values{
+		"variables": {string(variablesJson)},
+		"features":  {string(featuresJson)},
+	}.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return err
+	}
+	cookie, xcsrfToken := a.credentials()
+	req.Header.Set("User-Agent", twitterUserAgent)
+	req.Header.Set("Authorization", "Bearer "+twitterBearerToken)
+	req.Header.Set("Cookie", cookie)
+	req.Header.Set("x-csrf-token", xcsrfToken)
+	req.Header.Set("x-twitter-auth-type", "OAuth2Session")
+	req.Header.Set("x-twitter-active-user", "yes")
+
+	resp, err := graphqlClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	// same wording as twiscraper so isAuthError recognises it
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("request api failed %s: %s", resp.Status, content)
+	}
+	return json.Unmarshal(content, target)
+}
+
+type timelineEntry struct {
+	EntryId string `json:"entryId"`
+	Content struct {
+		EntryType  string `json:"entryType"`
+		Value      string `json:"value"`
+		CursorType string `json:"cursorType"`
+	} `json:"content"`
+}
+
+// parseTimeline returns the tweets of one page and the cursor of the next.
+func parseTimeline(t timeline) ([]entity.ParsedTweet, string) {
+	var tweets []entity.ParsedTweet
+	var next string
+
+	parseEntry := func(entryRaw json.RawMessage) {
+		var entry timelineEntry
+		if err := json.Unmarshal(entryRaw, &entry); err != nil {
+			return
+		}
+		switch entry.Content.EntryType {
+		case "TimelineTimelineCursor":
+			if entry.Content.CursorType == "Bottom" {
+				next = entry.Content.Value
+			}
+		case "TimelineTimelineItem", "TimelineTimelineModule":
+			var tweetEntry entity.TimelineTweetEntry
+			if err := json.Unmarshal(entryRaw, &tweetEntry); err != nil {
+				return
+			}
+			results := []*entity.TweetResults{tweetEntry.Content.ItemContent.TweetResults}
+			if tweetEntry.Content.ItemContent.PromotedMetadata != nil {
+				results = nil
+			}
+			for _, item := range tweetEntry.Content.Items {
+				if item.Item.ItemContent.PromotedMetadata == nil {
+					results = append(results, item.Item.ItemContent.TweetResults)
+				}
+			}
+			for _, result := range results {
+				if result == nil {
+					continue
+				}
+				tweet, err := result.Result.Parse()
+				if err != nil || tweet == nil {
+					continue
+				}
+				tweets = append(tweets, *tweet)
+			}
+		}
+	}
+
+	for _, instructionRaw := range t.Instructions {
+		var instruction struct {
+			Type    string            `json:"type"`
+			Entries []json.RawMessage `json:"entries"`
+			Entry   json.RawMessage   `json:"entry"`
+		}
+		if err := json.Unmarshal(instructionRaw, &instruction); err != nil {
+			continue
+		}
+		switch instruction.Type {
+		case "TimelineAddEntries":
+			for _, entryRaw := range instruction.Entries {
+				parseEntry(entryRaw)
+			}
+		case "TimelineReplaceEntry":
+			parseEntry(instruction.Entry)
+		}
+	}
+	return tweets, next
+}
@@ -1,9 +1,12 @@
 package main
 
 import (
+	"fmt"
 	"log"
 	"time"
 
+	"github.com/pkg/errors"
+
 	_ "modernc.org/sqlite"
 )
 
@@ -24,17 +27,48 @@ func main() {
 }
 
 func (bot *bot) loop() {
+	if bot.state.isPaused(pauseScrape) {
+		bot.scheduleLoop(time.Minute)
+		return
+	}
+	if bot.accounts.allExpired() {
+		bot.scheduleLoop(time.Minute)
+		return
+	}
+	if wait := bot.breaker.wait(); wait > 0 {
+		bot.scheduleLoop(wait)
+		return
+	}
 	if err := bot.cleanup(); err != nil {
-		log.Fatal(err)
+		log.Println("cleanup error", err)
 	}
 	count, err := bot.newLoop()
+	if errors.Is(err, errSessionExpired) {
+		bot.scheduleLoop(time.Minute)
+		return
+	}
 	if err != nil {
-		log.Fatal(err)
+		cooldown, tripped := bot.breaker.failure(err)
+		log.Println("newLoop error", err, "retry in", cooldown)
+		if tripped {
+			bot.tg.SendMessage(bot.ownerID, fmt.Sprintf("Scraping stopped: %s\nRetrying in %s", err.Error(), cooldown), nil)
+		}
+		bot.scheduleLoop(cooldown)
+		return
+	}
+	if bot.breaker.success() {
+		bot.tg.SendMessage(bot.ownerID, "Scraping recovered", nil)
 	}
+	bot.state.setLoopResult(count)
 	wait := 5 * time.Minute
 	if count == 0 {
 		wait = 15 * time.Minute
 	}
 
+	bot.scheduleLoop(wait)
+}
+
+func (bot *bot) scheduleLoop(wait time.Duration) {
+	bot.state.setNextLoop(time.Now().Add(wait))
 	time.AfterFunc(wait, bot.loop)
 }
@@ -0,0 +1,185 @@
+package main
+
+import (
+	"bufio"
+	"container/list"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Files are named by the sha256 of their content. Use times are kept in the
+// modification times, so the eviction order survives restarts.
+type mediaCache struct {
+	dir     string
+	maxSize int64
+
+	mu    sync.Mutex
+	urls  map[string]string
+	files map[string]*list.Element
+	// *cacheEntry, the most recently used first
+	lru   *list.List
+	size  int64
+	index *os.File
+}
+
+type cacheEntry struct {
+	hash string
+	size int64
+}
+
+func newMediaCache(dir string, maxSize int64) (*mediaCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	c := &mediaCache{
+		dir:     dir,
+		maxSize: maxSize,
+		urls:    make(map[string]string),
+		files:   make(map[string]*list.Element),
+		lru:     list.New(),
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	type file struct {
+		cacheEntry
+		usedAt time.Time
+	}
+	var files []file
+	for _, entry := range entries {
+		// downloads cut short by an earlier run
+		if strings.HasPrefix(entry.Name(), "download-") {
+			os.Remove(filepath.Join(dir, entry.Name()))
+			continue
+		}
+		if len(entry.Name()) != 64 {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			continue
+		}
+		files = append(files, file{cacheEntry{entry.Name(), info.Size()}, info.ModTime()})
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].usedAt.After(files[j].usedAt)
+	})
+	for _, f := range files {
+		entry := f.cacheEntry
+		c.files[entry.hash] = c.lru.PushBack(&entry)
+		c.size += entry.size
+	}
+
+	c.evict()
+	if err := c.loadIndex(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *mediaCache) loadIndex() error {
+	path := filepath.Join(c.dir, "index")
+	if f, err := os.Open(path); err == nil {
+		scanner := bufio.NewScanner(f)
+		for scanner.Scan() {
+			hash, url, ok := strings.Cut(scanner.Text(), " ")
+			if !ok {
+				continue
+			}
+			if _, ok := c.files[hash]; ok {
+				c.urls[url] = hash
+			}
+		}
+		f.Close()
+	} else if !os.IsNotExist(err) {
+		return err
+	}
+
+	index, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(index)
+	for url, hash := range c.urls {
+		fmt.Fprintf(w, "%s %s\n", hash, url)
+	}
+	if err := w.Flush(); err != nil {
+		index.Close()
+		return err
+	}
+	c.index = index
+	return nil
+}
+
+func (c *mediaCache) tempFile() (*os.File, error) {
+	return os.CreateTemp(c.dir, "download-*")
+}
+
+func (c *mediaCache) open(url string) (*os.File, int64, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	hash, ok := c.urls[url]
+	if !ok {
+		return nil, 0, false
+	}
+	e, ok := c.files[hash]
+	if !ok {
+		delete(c.urls, url)
+		return nil, 0, false
+	}
+	path := filepath.Join(c.dir, hash)
+	f, err := os.Open(path)
+	if err != nil {
+		c.remove(e)
+		return nil, 0, false
+	}
+	c.lru.MoveToFront(e)
+	now := time.Now()
+	os.Chtimes(path, now, now)
+	return f, e.Value.(*cacheEntry).size, true
+}
+
+func (c *mediaCache) put(url, tmp, hash string, size int64) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.files[hash]; ok {
+		os.Remove(tmp)
+		c.lru.MoveToFront(e)
+	} else {
+		if err := os.Rename(tmp, filepath.Join(c.dir, hash)); err != nil {
+			return err
+		}
+		c.files[hash] = c.lru.PushFront(&cacheEntry{hash, size})
+		c.size += size
+	}
+	if c.urls[url] != hash {
+		c.urls[url] = hash
+		if _, err := fmt.Fprintf(c.index, "%s %s\n", hash, url); err != nil {
+			return err
+		}
+	}
+	c.evict()
+	return nil
+}
+
+// the newest file is kept even when it alone is past maxSize
+func (c *mediaCache) evict() {
+	for c.size > c.maxSize && c.lru.Len() > 1 {
+		c.remove(c.lru.Back())
+	}
+}
+
+func (c *mediaCache) remove(e *list.Element) {
+	entry := e.Value.(*cacheEntry)
+	c.lru.Remove(e)
+	delete(c.files, entry.hash)
+	c.size -= entry.size
+	os.Remove(filepath.Join(c.dir, entry.hash))
+}
@@ -0,0 +1,203 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/pkg/errors"
+)
+
+// Telegram takes at most ten items per album, documents only together with
+// other documents, and animations not at all.
+const maxMediaGroupSize = 10
+
+type mediaGroup struct {
+	inputMedias []gotgbot.InputMedia
+	// positions of the items in the list that was split
+	indexes []int
+}
+
+type sentMediaGroup struct {
+	messages []gotgbot.Message
+	indexes  []int
+}
+
+// splitMediaGroups breaks the input medias into groups Telegram accepts. A
+// group starts wherever its first item was, so the caption of the first item
+// stays on the first group.
+func splitMediaGroups(inputMedias []gotgbot.InputMedia) []mediaGroup {
+	var groups []mediaGroup
+	open := make(map[string]int)
+	for i, inputMedia := range inputMedias {
+		kind := "media"
+		switch inputMedia.(type) {
+		case gotgbot.InputMediaDocument:
+			kind = "document"
+		case gotgbot.InputMediaAnimation:
+			kind = ""
+		}
+		if g, ok := open[kind]; ok && kind != "" && len(groups[g].inputMedias) < maxMediaGroupSize {
+			groups[g].inputMedias = append(groups[g].inputMedias, inputMedia)
+			groups[g].indexes = append(groups[g].indexes, i)
+			continue
+		}
+		open[kind] = len(groups)
+		groups = append(groups, mediaGroup{
+			inputMedias: []gotgbot.InputMedia{inputMedia},
+			indexes:     []int{i},
+		})
+	}
+	return groups
+}
+
+// sendMediaGroups sends the input medias in as few messages as Telegram
+// allows. files are where the media of each input media comes from, sent by
+// file id when Telegram has it already and uploaded otherwise or when the
+// file id is rejected. Videos Telegram refuses to take step down to their
+// smaller variants. The first group replies to replyTo when it is set, the
+// following groups reply to replyTo or else to the first group. The groups
+// sent before an error are returned with it.
+func (bot *bot) sendMediaGroups(chatID int64, inputMedias []gotgbot.InputMedia, files []mediaFile, replyTo int64) ([]sentMediaGroup, error) {
+	variants := make(videoVariants)
+	inputMedias, files = bot.checkVideos(inputMedias, files, variants)
+	inputMedias, files = bot.checkPhotos(inputMedias, files)
+	var sent []sentMediaGroup
+	for _, group := range splitMediaGroups(inputMedias) {
+		groupFiles := make([]mediaFile, len(group.indexes))
+		for i, index := range group.indexes {
+			groupFiles[i] = files[index]
+		}
+
+		var replyParameters *gotgbot.ReplyParameters
+		if replyTo != 0 {
+			replyParameters = &gotgbot.ReplyParameters{
+				MessageId:                replyTo,
+				AllowSendingWithoutReply: true,
+			}
+		}
+
+		attached, byID := bot.attachFiles(group.inputMedias, groupFiles, true)
+		msgs, err := bot.sendMediaGroup(chatID, attached, replyParameters)
+		closeInputMedias(attached)
+		if err != nil && byID {
+			log.Println("send by file id error", err)
+			attached, _ = bot.attachFiles(group.inputMedias, groupFiles, false)
+			msgs, err = bot.sendMediaGroup(chatID, attached, replyParameters)
+			closeInputMedias(attached)
+		}
+		for err != nil && !isFloodWait(err) && bot.stepDownVideos(group.inputMedias, groupFiles, variants) {
+			log.Println("send media group error", err)
+			attached, _ = bot.attachFiles(group.inputMedias, groupFiles, false)
+			msgs, err = bot.sendMediaGroup(chatID, attached, replyParameters)
+			closeInputMedias(attached)
+		}
+		if err != nil {
+			return sent, err
+		}
+		if len(msgs) == 0 {
+			return sent, errors.New("no message sent")
+		}
+		bot.saveFileIDs(group.inputMedias, groupFiles, msgs)
+		sent = append(sent, sentMediaGroup{
+			messages: msgs,
+			indexes:  group.indexes,
+		})
+		if replyTo == 0 {
+			replyTo = msgs[0].MessageId
+		}
+	}
+	return sent, nil
+}
+
+// isFloodWait tells whether Telegram only asked to slow down, which no
+// smaller file helps with.
+func isFloodWait(err error) bool {
+	var tgErr *gotgbot.TelegramError
+	return errors.As(err, &tgErr) && tgErr.Code == http.StatusTooManyRequests
+}
+
+// sendMediaGroup sends a group of input medias as an album, or as a single
+// message when it is only one.
+func (bot *bot) sendMediaGroup(chatID int64, inputMedias []gotgbot.InputMedia, replyParameters *gotgbot.ReplyParameters) ([]gotgbot.Message, error) {
+	if len(inputMedias) > 1 {
+		return bot.tg.SendMediaGroup(chatID, inputMedias, &gotgbot.SendMediaGroupOpts{
+			ReplyParameters: replyParameters,
+		})
+	}
+	msg, err := bot.sendMedia(chatID, inputMedias[0], replyParameters)
+	if err != nil || msg == nil {
+		return nil, err
+	}
+	return []gotgbot.Message{*msg}, nil
+}
+
+// sendFollowUps sends the text that did not fit into a caption as replies to
+// the post.
+func (bot *bot) sendFollowUps(chatID int64, replyTo int64, followUps []richText) error {
+	for _, followUp := range followUps {
+		if _, err := bot.tg.SendMessage(chatID, followUp.text, &gotgbot.SendMessageOpts{
+			Entities: followUp.entities,
+			LinkPreviewOptions: &gotgbot.LinkPreviewOptions{
+				IsDisabled: true,
+			},
+			ReplyParameters: &gotgbot.ReplyParameters{
+				MessageId:                replyTo,
+				AllowSendingWithoutReply: true,
+			},
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sendMedia sends a single input media with the matching method.
+func (bot *bot) sendMedia(chatID int64, inputMedia gotgbot.InputMedia, replyParameters *gotgbot.ReplyParameters) (*gotgbot.Message, error) {
+	switch media := inputMedia.(type) {
+	case gotgbot.InputMediaPhoto:
+		return bot.tg.SendPhoto(chatID, media.Media, &gotgbot.SendPhotoOpts{
+			Caption:         media.Caption,
+			ParseMode:       media.ParseMode,
+			CaptionEntities: media.CaptionEntities,
+			HasSpoiler:      media.HasSpoiler,
+			ReplyParameters: replyParameters,
+		})
+	case gotgbot.InputMediaVideo:
+		var cover gotgbot.InputFileOrString
+		if media.Cover != "" {
+			cover = gotgbot.InputFileByURL(media.Cover)
+		}
+		return bot.tg.SendVideo(chatID, media.Media, &gotgbot.SendVideoOpts{
+			Caption:         media.Caption,
+			ParseMode:       media.ParseMode,
+			CaptionEntities: media.CaptionEntities,
+			Width:           media.Width,
+			Height:          media.Height,
+			Duration:        media.Duration,
+			Cover:           cover,
+			HasSpoiler:      media.HasSpoiler,
+			ReplyParameters: replyParameters,
+		})
+	case gotgbot.InputMediaAnimation:
+		return bot.tg.SendAnimation(chatID, media.Media, &gotgbot.SendAnimationOpts{
+			Caption:         media.Caption,
+			ParseMode:       media.ParseMode,
+			CaptionEntities: media.CaptionEntities,
+			Width:           media.Width,
+			Height:          media.Height,
+			Duration:        media.Duration,
+			HasSpoiler:      media.HasSpoiler,
+			ReplyParameters: replyParameters,
+		})
+	case gotgbot.InputMediaDocument:
+		return bot.tg.SendDocument(chatID, media.Media, &gotgbot.SendDocumentOpts{
+			Caption:         media.Caption,
+			ParseMode:       media.ParseMode,
+			CaptionEntities: media.CaptionEntities,
+			ReplyParameters: replyParameters,
+		})
+	}
+	return nil, fmt.Errorf("unknown media type %T", inputMedia)
+}
@@ -0,0 +1,8 @@
+-- Adds the dHash, aHash and colour histogram next to the pHash of the
+-- images table. Rows from before have none, their similarity is scored by
+-- the pHash alone.
+
+ALTER TABLE images
+	ADD COLUMN d_hash BIGINT,
+	ADD COLUMN a_hash BIGINT,
+	ADD COLUMN histogram BYTEA;
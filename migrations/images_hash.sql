@@ -0,0 +1,17 @@
+-- Moves the pHash of the images table from four 16-bit hex columns into one
+-- BIGINT, so similar images are searched by the number of differing bits.
+-- bit_count needs PostgreSQL 14 or later.
+
+BEGIN;
+
+ALTER TABLE images ADD COLUMN hash BIGINT;
+UPDATE images SET hash = ('x' || hash_a || hash_b || hash_c || hash_d)::bit(64)::bigint;
+ALTER TABLE images ALTER COLUMN hash SET NOT NULL;
+ALTER TABLE images
+	DROP COLUMN hash_a,
+	DROP COLUMN hash_b,
+	DROP COLUMN hash_c,
+	DROP COLUMN hash_d;
+CREATE INDEX images_chat_id_idx ON images (chat_id);
+
+COMMIT;
@@ -0,0 +1,4 @@
+-- Marks the posts whose photos were fingerprinted, so /backfill only
+-- fetches the photos of the others again.
+
+ALTER TABLE posts ADD COLUMN fingerprinted BOOLEAN NOT NULL DEFAULT false;
@@ -12,74 +12,110 @@ import "testing"
 // It does NOT run each operation group in parallel.
 // Separating the tests thusly grants avoidance of Postgres deadlocks.
 func TestParent(t *testing.T) {
+	t.Run("FileIDs", testFileIDs)
 	t.Run("Images", testImages)
+	t.Run("Posts", testPosts)
+	t.Run("TimelineCursors", testTimelineCursors)
 	t.Run("Tweets", testTweets)
 	t.Run("Unfolloweds", testUnfolloweds)
 }
 
 func TestDelete(t *testing.T) {
+	t.Run("FileIDs", testFileIDsDelete)
 	t.Run("Images", testImagesDelete)
+	t.Run("Posts", testPostsDelete)
+	t.Run("TimelineCursors", testTimelineCursorsDelete)
 	t.Run("Tweets", testTweetsDelete)
 	t.Run("Unfolloweds", testUnfollowedsDelete)
 }
 
 func TestQueryDeleteAll(t *testing.T) {
+	t.Run("FileIDs", testFileIDsQueryDeleteAll)
 	t.Run("Images", testImagesQueryDeleteAll)
+	t.Run("Posts", testPostsQueryDeleteAll)
+	t.Run("TimelineCursors", testTimelineCursorsQueryDeleteAll)
 	t.Run("Tweets", testTweetsQueryDeleteAll)
 	t.Run("Unfolloweds", testUnfollowedsQueryDeleteAll)
 }
 
 func TestSliceDeleteAll(t *testing.T) {
+	t.Run("FileIDs", testFileIDsSliceDeleteAll)
 	t.Run("Images", testImagesSliceDeleteAll)
+	t.Run("Posts", testPostsSliceDeleteAll)
+	t.Run("TimelineCursors", testTimelineCursorsSliceDeleteAll)
 	t.Run("Tweets", testTweetsSliceDeleteAll)
 	t.Run("Unfolloweds", testUnfollowedsSliceDeleteAll)
 }
 
 func TestExists(t *testing.T) {
+	t.Run("FileIDs", testFileIDsExists)
 	t.Run("Images", testImagesExists)
+	t.Run("Posts", testPostsExists)
+	t.Run("TimelineCursors", testTimelineCursorsExists)
 	t.Run("Tweets", testTweetsExists)
 	t.Run("Unfolloweds", testUnfollowedsExists)
 }
 
 func TestFind(t *testing.T) {
+	t.Run("FileIDs", testFileIDsFind)
 	t.Run("Images", testImagesFind)
+	t.Run("Posts", testPostsFind)
+	t.Run("TimelineCursors", testTimelineCursorsFind)
 	t.Run("Tweets", testTweetsFind)
 	t.Run("Unfolloweds", testUnfollowedsFind)
 }
 
 func TestBind(t *testing.T) {
+	t.Run("FileIDs", testFileIDsBind)
 	t.Run("Images", testImagesBind)
+	t.Run("Posts", testPostsBind)
+	t.Run("TimelineCursors", testTimelineCursorsBind)
 	t.Run("Tweets", testTweetsBind)
 	t.Run("Unfolloweds", testUnfollowedsBind)
 }
 
 func TestOne(t *testing.T) {
+	t.Run("FileIDs", testFileIDsOne)
 	t.Run("Images", testImagesOne)
+	t.Run("Posts", testPostsOne)
+	t.Run("TimelineCursors", testTimelineCursorsOne)
 	t.Run("Tweets", testTweetsOne)
 	t.Run("Unfolloweds", testUnfollowedsOne)
 }
 
 func TestAll(t *testing.T) {
+	t.Run("FileIDs", testFileIDsAll)
 	t.Run("Images", testImagesAll)
+	t.Run("Posts", testPostsAll)
+	t.Run("TimelineCursors", testTimelineCursorsAll)
 	t.Run("Tweets", testTweetsAll)
 	t.Run("Unfolloweds", testUnfollowedsAll)
 }
 
 func TestCount(t *testing.T) {
+	t.Run("FileIDs", testFileIDsCount)
 	t.Run("Images", testImagesCount)
+	t.Run("Posts", testPostsCount)
+	t.Run("TimelineCursors", testTimelineCursorsCount)
 	t.Run("Tweets", testTweetsCount)
 	t.Run("Unfolloweds", testUnfollowedsCount)
 }
 
 func TestHooks(t *testing.T) {
+	t.Run("FileIDs", testFileIDsHooks)
 	t.Run("Images", testImagesHooks)
+	t.Run("Posts", testPostsHooks)
+	t.Run("TimelineCursors", testTimelineCursorsHooks)
 	t.Run("Tweets", testTweetsHooks)
 	t.Run("Unfolloweds", testUnfollowedsHooks)
 }
 
 func TestInsert(t *testing.T) {
+	t.Run("FileIDs", testFileIDsInsert)
 	t.Run("Images", testImagesInsert)
 	t.Run("Images", testImagesInsertWhitelist)
+	t.Run("Posts", testPostsInsert)
+	t.Run("TimelineCursors", testTimelineCursorsInsert)
 	t.Run("Tweets", testTweetsInsert)
 	t.Run("Tweets", testTweetsInsertWhitelist)
 	t.Run("Unfolloweds", testUnfollowedsInsert)
@@ -87,31 +123,46 @@ func TestInsert(t *testing.T) {
 }
 
 func TestReload(t *testing.T) {
+	t.Run("FileIDs", testFileIDsReload)
 	t.Run("Images", testImagesReload)
+	t.Run("Posts", testPostsReload)
+	t.Run("TimelineCursors", testTimelineCursorsReload)
 	t.Run("Tweets", testTweetsReload)
 	t.Run("Unfolloweds", testUnfollowedsReload)
 }
 
 func TestReloadAll(t *testing.T) {
+	t.Run("FileIDs", testFileIDsReloadAll)
 	t.Run("Images", testImagesReloadAll)
+	t.Run("Posts", testPostsReloadAll)
+	t.Run("TimelineCursors", testTimelineCursorsReloadAll)
 	t.Run("Tweets", testTweetsReloadAll)
 	t.Run("Unfolloweds", testUnfollowedsReloadAll)
 }
 
 func TestSelect(t *testing.T) {
+	t.Run("FileIDs", testFileIDsSelect)
 	t.Run("Images", testImagesSelect)
+	t.Run("Posts", testPostsSelect)
+	t.Run("TimelineCursors", testTimelineCursorsSelect)
 	t.Run("Tweets", testTweetsSelect)
 	t.Run("Unfolloweds", testUnfollowedsSelect)
 }
 
 func TestUpdate(t *testing.T) {
+	t.Run("FileIDs", testFileIDsUpdate)
 	t.Run("Images", testImagesUpdate)
+	t.Run("Posts", testPostsUpdate)
+	t.Run("TimelineCursors", testTimelineCursorsUpdate)
 	t.Run("Tweets", testTweetsUpdate)
 	t.Run("Unfolloweds", testUnfollowedsUpdate)
 }
 
 func TestSliceUpdateAll(t *testing.T) {
+	t.Run("FileIDs", testFileIDsSliceUpdateAll)
 	t.Run("Images", testImagesSliceUpdateAll)
+	t.Run("Posts", testPostsSliceUpdateAll)
+	t.Run("TimelineCursors", testTimelineCursorsSliceUpdateAll)
 	t.Run("Tweets", testTweetsSliceUpdateAll)
 	t.Run("Unfolloweds", testUnfollowedsSliceUpdateAll)
 }
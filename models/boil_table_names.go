@@ -4,11 +4,17 @@
 package models
 
 var TableNames = struct {
-	Images     string
-	Tweets     string
-	Unfollowed string
+	FileIDs         string
+	Images          string
+	Posts           string
+	TimelineCursors string
+	Tweets          string
+	Unfollowed      string
 }{
-	Images:     "images",
-	Tweets:     "tweets",
-	Unfollowed: "unfollowed",
+	FileIDs:         "file_ids",
+	Images:          "images",
+	Posts:           "posts",
+	TimelineCursors: "timeline_cursors",
+	Tweets:          "tweets",
+	Unfollowed:      "unfollowed",
 }
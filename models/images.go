@@ -14,6 +14,7 @@ import (
 	"time"
 
 	"github.com/friendsofgo/errors"
+	"github.com/volatiletech/null/v8"
 	"github.com/volatiletech/sqlboiler/v4/boil"
 	"github.com/volatiletech/sqlboiler/v4/queries"
 	"github.com/volatiletech/sqlboiler/v4/queries/qm"
@@ -23,15 +24,15 @@ import (
 
 // Image is an object representing the database table.
 type Image struct {
-	ID        int       `boil:"id" json:"id" toml:"id" yaml:"id"`
-	HashA     string    `boil:"hash_a" json:"hash_a" toml:"hash_a" yaml:"hash_a"`
-	HashB     string    `boil:"hash_b" json:"hash_b" toml:"hash_b" yaml:"hash_b"`
-	HashC     string    `boil:"hash_c" json:"hash_c" toml:"hash_c" yaml:"hash_c"`
-	HashD     string    `boil:"hash_d" json:"hash_d" toml:"hash_d" yaml:"hash_d"`
-	ChatID    int64     `boil:"chat_id" json:"chat_id" toml:"chat_id" yaml:"chat_id"`
-	MessageID int64     `boil:"message_id" json:"message_id" toml:"message_id" yaml:"message_id"`
-	CreatedAt time.Time `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
-	UpdatedAt time.Time `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
+	ID        int        `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Hash      int64      `boil:"hash" json:"hash" toml:"hash" yaml:"hash"`
+	DHash     null.Int64 `boil:"d_hash" json:"d_hash,omitempty" toml:"d_hash" yaml:"d_hash,omitempty"`
+	AHash     null.Int64 `boil:"a_hash" json:"a_hash,omitempty" toml:"a_hash" yaml:"a_hash,omitempty"`
+	Histogram null.Bytes `boil:"histogram" json:"histogram,omitempty" toml:"histogram" yaml:"histogram,omitempty"`
+	ChatID    int64      `boil:"chat_id" json:"chat_id" toml:"chat_id" yaml:"chat_id"`
+	MessageID int64      `boil:"message_id" json:"message_id" toml:"message_id" yaml:"message_id"`
+	CreatedAt time.Time  `boil:"created_at" json:"created_at" toml:"created_at" yaml:"created_at"`
+	UpdatedAt time.Time  `boil:"updated_at" json:"updated_at" toml:"updated_at" yaml:"updated_at"`
 
 	R *imageR `boil:"-" json:"-" toml:"-" yaml:"-"`
 	L imageL  `boil:"-" json:"-" toml:"-" yaml:"-"`
@@ -39,20 +40,20 @@ type Image struct {
 
 var ImageColumns = struct {
 	ID        string
-	HashA     string
-	HashB     string
-	HashC     string
-	HashD     string
+	Hash      string
+	DHash     string
+	AHash     string
+	Histogram string
 	ChatID    string
 	MessageID string
 	CreatedAt string
 	UpdatedAt string
 }{
 	ID:        "id",
-	HashA:     "hash_a",
-	HashB:     "hash_b",
-	HashC:     "hash_c",
-	HashD:     "hash_d",
+	Hash:      "hash",
+	DHash:     "d_hash",
+	AHash:     "a_hash",
+	Histogram: "histogram",
 	ChatID:    "chat_id",
 	MessageID: "message_id",
 	CreatedAt: "created_at",
@@ -61,20 +62,20 @@ var ImageColumns = struct {
 
 var ImageTableColumns = struct {
 	ID        string
-	HashA     string
-	HashB     string
-	HashC     string
-	HashD     string
+	Hash      string
+	DHash     string
+	AHash     string
+	Histogram string
 	ChatID    string
 	MessageID string
 	CreatedAt string
 	UpdatedAt string
 }{
 	ID:        "images.id",
-	HashA:     "images.hash_a",
-	HashB:     "images.hash_b",
-	HashC:     "images.hash_c",
-	HashD:     "images.hash_d",
+	Hash:      "images.hash",
+	DHash:     "images.d_hash",
+	AHash:     "images.a_hash",
+	Histogram: "images.histogram",
 	ChatID:    "images.chat_id",
 	MessageID: "images.message_id",
 	CreatedAt: "images.created_at",
@@ -106,26 +107,22 @@ func (w whereHelperint) NIN(slice []int) qm.QueryMod {
 	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
 }
 
-type whereHelperstring struct{ field string }
-
-func (w whereHelperstring) EQ(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperstring) NEQ(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperstring) LT(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperstring) LTE(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperstring) GT(x string) qm.QueryMod     { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperstring) GTE(x string) qm.QueryMod    { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperstring) LIKE(x string) qm.QueryMod   { return qm.Where(w.field+" LIKE ?", x) }
-func (w whereHelperstring) NLIKE(x string) qm.QueryMod  { return qm.Where(w.field+" NOT LIKE ?", x) }
-func (w whereHelperstring) ILIKE(x string) qm.QueryMod  { return qm.Where(w.field+" ILIKE ?", x) }
-func (w whereHelperstring) NILIKE(x string) qm.QueryMod { return qm.Where(w.field+" NOT ILIKE ?", x) }
-func (w whereHelperstring) IN(slice []string) qm.QueryMod {
+type whereHelperint64 struct{ field string }
+
+func (w whereHelperint64) EQ(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperint64) NEQ(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperint64) LT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperint64) LTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperint64) GT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperint64) GTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+func (w whereHelperint64) IN(slice []int64) qm.QueryMod {
 	values := make([]interface{}, 0, len(slice))
 	for _, value := range slice {
 		values = append(values, value)
 	}
 	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
 }
-func (w whereHelperstring) NIN(slice []string) qm.QueryMod {
+func (w whereHelperint64) NIN(slice []int64) qm.QueryMod {
 	values := make([]interface{}, 0, len(slice))
 	for _, value := range slice {
 		values = append(values, value)
@@ -133,22 +130,34 @@ func (w whereHelperstring) NIN(slice []string) qm.QueryMod {
 	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
 }
 
-type whereHelperint64 struct{ field string }
+type whereHelpernull_Int64 struct{ field string }
 
-func (w whereHelperint64) EQ(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
-func (w whereHelperint64) NEQ(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
-func (w whereHelperint64) LT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
-func (w whereHelperint64) LTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
-func (w whereHelperint64) GT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
-func (w whereHelperint64) GTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
-func (w whereHelperint64) IN(slice []int64) qm.QueryMod {
+func (w whereHelpernull_Int64) EQ(x null.Int64) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Int64) NEQ(x null.Int64) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Int64) LT(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Int64) LTE(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Int64) GT(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Int64) GTE(x null.Int64) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+func (w whereHelpernull_Int64) IN(slice []int64) qm.QueryMod {
 	values := make([]interface{}, 0, len(slice))
 	for _, value := range slice {
 		values = append(values, value)
 	}
 	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
 }
-func (w whereHelperint64) NIN(slice []int64) qm.QueryMod {
+func (w whereHelpernull_Int64) NIN(slice []int64) qm.QueryMod {
 	values := make([]interface{}, 0, len(slice))
 	for _, value := range slice {
 		values = append(values, value)
@@ -156,6 +165,33 @@ func (w whereHelperint64) NIN(slice []int64) qm.QueryMod {
 	return qm.WhereNotIn(fmt.Sprintf("%s NOT IN ?", w.field), values...)
 }
 
+func (w whereHelpernull_Int64) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Int64) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
+type whereHelpernull_Bytes struct{ field string }
+
+func (w whereHelpernull_Bytes) EQ(x null.Bytes) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Bytes) NEQ(x null.Bytes) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Bytes) LT(x null.Bytes) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Bytes) LTE(x null.Bytes) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Bytes) GT(x null.Bytes) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Bytes) GTE(x null.Bytes) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+func (w whereHelpernull_Bytes) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Bytes) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+
 type whereHelpertime_Time struct{ field string }
 
 func (w whereHelpertime_Time) EQ(x time.Time) qm.QueryMod {
@@ -179,20 +215,20 @@ func (w whereHelpertime_Time) GTE(x time.Time) qm.QueryMod {
 
 var ImageWhere = struct {
 	ID        whereHelperint
-	HashA     whereHelperstring
-	HashB     whereHelperstring
-	HashC     whereHelperstring
-	HashD     whereHelperstring
+	Hash      whereHelperint64
+	DHash     whereHelpernull_Int64
+	AHash     whereHelpernull_Int64
+	Histogram whereHelpernull_Bytes
 	ChatID    whereHelperint64
 	MessageID whereHelperint64
 	CreatedAt whereHelpertime_Time
 	UpdatedAt whereHelpertime_Time
 }{
 	ID:        whereHelperint{field: "\"images\".\"id\""},
-	HashA:     whereHelperstring{field: "\"images\".\"hash_a\""},
-	HashB:     whereHelperstring{field: "\"images\".\"hash_b\""},
-	HashC:     whereHelperstring{field: "\"images\".\"hash_c\""},
-	HashD:     whereHelperstring{field: "\"images\".\"hash_d\""},
+	Hash:      whereHelperint64{field: "\"images\".\"hash\""},
+	DHash:     whereHelpernull_Int64{field: "\"images\".\"d_hash\""},
+	AHash:     whereHelpernull_Int64{field: "\"images\".\"a_hash\""},
+	Histogram: whereHelpernull_Bytes{field: "\"images\".\"histogram\""},
 	ChatID:    whereHelperint64{field: "\"images\".\"chat_id\""},
 	MessageID: whereHelperint64{field: "\"images\".\"message_id\""},
 	CreatedAt: whereHelpertime_Time{field: "\"images\".\"created_at\""},
@@ -216,8 +252,8 @@ func (*imageR) NewStruct() *imageR {
 type imageL struct{}
 
 var (
-	imageAllColumns            = []string{"id", "hash_a", "hash_b", "hash_c", "hash_d", "chat_id", "message_id", "created_at", "updated_at"}
-	imageColumnsWithoutDefault = []string{"hash_a", "hash_b", "hash_c", "hash_d", "chat_id", "message_id", "created_at", "updated_at"}
+	imageAllColumns            = []string{"id", "hash", "d_hash", "a_hash", "histogram", "chat_id", "message_id", "created_at", "updated_at"}
+	imageColumnsWithoutDefault = []string{"hash", "d_hash", "a_hash", "histogram", "chat_id", "message_id", "created_at", "updated_at"}
 	imageColumnsWithDefault    = []string{"id"}
 	imagePrimaryKeyColumns     = []string{"id"}
 	imageGeneratedColumns      = []string{}
@@ -568,7 +568,7 @@ func testImagesSelect(t *testing.T) {
 }
 
 var (
-	imageDBTypes = map[string]string{`ID`: `integer`, `HashA`: `text`, `HashB`: `text`, `HashC`: `text`, `HashD`: `text`, `ChatID`: `bigint`, `MessageID`: `bigint`, `CreatedAt`: `timestamp without time zone`, `UpdatedAt`: `timestamp without time zone`}
+	imageDBTypes = map[string]string{`ID`: `integer`, `Hash`: `bigint`, `DHash`: `bigint`, `AHash`: `bigint`, `Histogram`: `bytea`, `ChatID`: `bigint`, `MessageID`: `bigint`, `CreatedAt`: `timestamp without time zone`, `UpdatedAt`: `timestamp without time zone`}
 	_            = bytes.MinRead
 )
 
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	_ "image/png"
+	"log"
+	"os"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/nfnt/resize"
+	"github.com/pkg/errors"
+)
+
+// What Telegram takes as a photo.
+const (
+	maxPhotoSize       = 10 * 1024 * 1024
+	maxPhotoDimensions = 10000
+	maxPhotoRatio      = 20
+)
+
+// bounds the canvas a photo is padded onto
+const maxNormalizePixels = 64 * 1024 * 1024
+
+func photoFit(cfg image.Config, size int64) (fits, fixable bool) {
+	w, h := cfg.Width, cfg.Height
+	if w <= 0 || h <= 0 {
+		return false, false
+	}
+	if size <= maxPhotoSize && w+h <= maxPhotoDimensions && max(w, h) <= maxPhotoRatio*min(w, h) {
+		return true, true
+	}
+	padded := paddedBounds(image.Rect(0, 0, w, h))
+	return false, padded.Dx()*padded.Dy() <= maxNormalizePixels
+}
+
+// runs before the medias are split into albums, documents do not mix with photos
+func (bot *bot) checkPhotos(inputMedias []gotgbot.InputMedia, files []mediaFile) ([]gotgbot.InputMedia, []mediaFile) {
+	checked := make([]gotgbot.InputMedia, len(inputMedias))
+	checkedFiles := make([]mediaFile, len(files))
+	copy(checked, inputMedias)
+	copy(checkedFiles, files)
+	for i, inputMedia := range inputMedias {
+		photo, ok := inputMedia.(gotgbot.InputMediaPhoto)
+		if !ok {
+			continue
+		}
+		if fileID, err := bot.getFileID(files[i].url, "photo"); err == nil && fileID != "" {
+			continue
+		}
+		f, size, err := bot.fetcher.fetch(files[i].url)
+		if err != nil {
+			continue
+		}
+		cfg, _, err := image.DecodeConfig(f)
+		f.Close()
+		fits, fixable := photoFit(cfg, size)
+		if err == nil && fits {
+			continue
+		}
+		if err == nil && fixable {
+			// a copy that fails later would be sent by url and refused
+			normalized, _, err := bot.fetcher.openNormalized(files[i])
+			if err == nil {
+				normalized.Close()
+				checkedFiles[i].normalize = true
+				continue
+			}
+			log.Println("normalizePhoto error", files[i].url, err)
+		}
+		log.Println("send photo as document", files[i].url, cfg.Width, cfg.Height)
+		checked[i] = gotgbot.InputMediaDocument{
+			Caption:         photo.Caption,
+			ParseMode:       photo.ParseMode,
+			CaptionEntities: photo.CaptionEntities,
+		}
+	}
+	return checked, checkedFiles
+}
+
+func keepRatio(w, h int) (int, int) {
+	return max(w, (h+maxPhotoRatio-1)/maxPhotoRatio), max(h, (w+maxPhotoRatio-1)/maxPhotoRatio)
+}
+
+// no scaling fixes the aspect ratio, the short side is padded instead
+func paddedBounds(b image.Rectangle) image.Rectangle {
+	w, h := b.Dx(), b.Dy()
+	switch {
+	case w > maxPhotoRatio*h:
+		pad := (w+maxPhotoRatio-1)/maxPhotoRatio - h
+		return image.Rect(b.Min.X, b.Min.Y-pad/2, b.Max.X, b.Max.Y+pad-pad/2)
+	case h > maxPhotoRatio*w:
+		pad := (h+maxPhotoRatio-1)/maxPhotoRatio - w
+		return image.Rect(b.Min.X-pad/2, b.Min.Y, b.Max.X+pad-pad/2, b.Max.Y)
+	}
+	return b
+}
+
+func normalizePhoto(src image.Image) ([]byte, error) {
+	b := paddedBounds(src.Bounds())
+	w, h := b.Dx(), b.Dy()
+	if w*h > maxNormalizePixels {
+		return nil, errors.Errorf("photo of %dx%d is too large to pad", src.Bounds().Dx(), src.Bounds().Dy())
+	}
+	if w+h > maxPhotoDimensions {
+		scale := float64(maxPhotoDimensions) / float64(w+h)
+		w, h = keepRatio(int(float64(w)*scale), int(float64(h)*scale))
+	}
+
+	// JPEG has no alpha, transparency and padding go white
+	flat := image.NewRGBA(b)
+	draw.Draw(flat, b, &image.Uniform{color.White}, image.Point{}, draw.Src)
+	draw.Draw(flat, src.Bounds(), src, src.Bounds().Min, draw.Over)
+
+	for {
+		img := image.Image(flat)
+		if w != b.Dx() || h != b.Dy() {
+			img = resize.Resize(uint(w), uint(h), flat, resize.Lanczos3)
+		}
+		for _, quality := range []int{92, 85, 75} {
+			buf := new(bytes.Buffer)
+			if err := jpeg.Encode(buf, img, &jpeg.Options{Quality: quality}); err != nil {
+				return nil, err
+			}
+			if buf.Len() <= maxPhotoSize {
+				return buf.Bytes(), nil
+			}
+		}
+		if w < 2 || h < 2 {
+			return nil, errors.New("photo does not get small enough")
+		}
+		w, h = keepRatio(w*3/4, h*3/4)
+	}
+}
+
+func (f *fetcher) openNormalized(file mediaFile) (*os.File, int64, error) {
+	key := file.url + "#photo"
+	if cached, size, ok := f.cache.open(key); ok {
+		return cached, size, nil
+	}
+
+	original, _, err := f.fetch(file.url)
+	if err != nil {
+		return nil, 0, err
+	}
+	img, _, err := image.Decode(original)
+	original.Close()
+	if err != nil {
+		return nil, 0, errors.Wrap(err, "failed to decode photo")
+	}
+	data, err := normalizePhoto(img)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	tmp, err := f.cache.tempFile()
+	if err != nil {
+		return nil, 0, err
+	}
+	_, err = tmp.Write(data)
+	tmp.Close()
+	if err != nil {
+		os.Remove(tmp.Name())
+		return nil, 0, err
+	}
+	hash := sha256.Sum256(data)
+	if err := f.cache.put(key, tmp.Name(), hex.EncodeToString(hash[:]), int64(len(data))); err != nil {
+		os.Remove(tmp.Name())
+		return nil, 0, err
+	}
+	cached, size, ok := f.cache.open(key)
+	if !ok {
+		return nil, 0, errors.Errorf("%s is gone from the cache", key)
+	}
+	return cached, size, nil
+}
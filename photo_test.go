@@ -0,0 +1,58 @@
+package main
+
+import (
+	"image"
+	"testing"
+)
+
+func TestPhotoFit(t *testing.T) {
+	tests := []struct {
+		name          string
+		w, h          int
+		size          int64
+		fits, fixable bool
+	}{
+		{"fits", 1200, 800, 1 << 20, true, true},
+		{"no size", 0, 800, 1 << 20, false, false},
+		{"too heavy", 1200, 800, maxPhotoSize + 1, false, true},
+		{"at the dimension limit", 5000, 5000, 1 << 20, true, true},
+		{"past the dimension limit", 5001, 5000, 1 << 20, false, true},
+		{"at the ratio limit", 8000, 400, 1 << 20, true, true},
+		{"past the ratio limit", 8001, 400, 1 << 20, false, true},
+		{"tall comic page", 800, 20000, 1 << 20, false, true},
+		{"too tall to pad", 800, 60000, 1 << 20, false, false},
+	}
+	for _, tt := range tests {
+		fits, fixable := photoFit(image.Config{Width: tt.w, Height: tt.h}, tt.size)
+		if fits != tt.fits || fixable != tt.fixable {
+			t.Errorf("%s: fits %v, fixable %v, want %v, %v", tt.name, fits, fixable, tt.fits, tt.fixable)
+		}
+	}
+}
+
+func TestPaddedBounds(t *testing.T) {
+	tests := []struct {
+		name string
+		b    image.Rectangle
+		want image.Rectangle
+	}{
+		{"within the ratio", image.Rect(0, 0, 2000, 100), image.Rect(0, 0, 2000, 100)},
+		{"wide", image.Rect(0, 0, 2001, 100), image.Rect(0, 0, 2001, 101)},
+		{"wide, padded on both sides", image.Rect(0, 0, 4000, 100), image.Rect(0, -50, 4000, 150)},
+		{"tall", image.Rect(0, 0, 10, 1000), image.Rect(-20, 0, 30, 1000)},
+		{"tall, odd padding", image.Rect(0, 0, 10, 1010), image.Rect(-20, 0, 31, 1010)},
+		{"offset", image.Rect(5, 5, 15, 1005), image.Rect(-15, 5, 35, 1005)},
+	}
+	for _, tt := range tests {
+		got := paddedBounds(tt.b)
+		if got != tt.want {
+			t.Errorf("%s: %v, want %v", tt.name, got, tt.want)
+		}
+		if w, h := got.Dx(), got.Dy(); max(w, h) > maxPhotoRatio*min(w, h) {
+			t.Errorf("%s: %v is past the ratio", tt.name, got)
+		}
+		if !tt.b.In(got) {
+			t.Errorf("%s: %v does not hold %v", tt.name, got, tt.b)
+		}
+	}
+}
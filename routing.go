@@ -0,0 +1,233 @@
+package main
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"twitter-bot/models"
+
+	"github.com/JasonKhew96/twiscraper/entity"
+	"github.com/pkg/errors"
+	"github.com/volatiletech/sqlboiler/v4/boil"
+)
+
+// destination is a channel with its discussion group. A tweet goes to every
+// configured destination it matches, and to the main channel when it matches
+// none.
+type destination struct {
+	name          string
+	channelChatID int64
+	groupChatID   int64
+	// nil when only the popularity of the source counts
+	popularity *popularity
+	// none matches every tweet
+	rules []routingRule
+}
+
+type routingRule struct {
+	authors   []string
+	hashtags  []string
+	text      *regexp.Regexp
+	media     map[string]bool
+	sensitive *bool
+}
+
+func newDestinations(configs []DestinationConfig) ([]*destination, error) {
+	var destinations []*destination
+	for _, c := range configs {
+		d := &destination{
+			name:          c.Name,
+			channelChatID: c.ChannelChatID,
+			groupChatID:   c.GroupChatID,
+		}
+		if c.PopularTweetFactor > 0 {
+			d.popularity = &popularity{
+				tweetFactor:   c.PopularTweetFactor,
+				retweetFactor: c.PopularRetweetFactor,
+				quoteFactor:   c.PopularQuoteFactor,
+			}
+		}
+		for i, r := range c.Rules {
+			rule := routingRule{
+				authors:   r.Authors,
+				sensitive: r.Sensitive,
+			}
+			for _, hashtag := range r.Hashtags {
+				rule.hashtags = append(rule.hashtags, strings.TrimLeft(hashtag, "#＃"))
+			}
+			if r.Text != "" {
+				text, err := regexp.Compile(r.Text)
+				if err != nil {
+					return nil, errors.Wrapf(err, "destination %s rules[%d].text is invalid", c.Name, i)
+				}
+				rule.text = text
+			}
+			if len(r.Media) > 0 {
+				rule.media = make(map[string]bool)
+				for _, media := range r.Media {
+					if media != "photo" && media != "video" && media != "gif" {
+						return nil, errors.Errorf("destination %s rules[%d] has unknown media %s", c.Name, i, media)
+					}
+					rule.media[media] = true
+				}
+			}
+			d.rules = append(d.rules, rule)
+		}
+		destinations = append(destinations, d)
+	}
+	return destinations, nil
+}
+
+func (d *destination) match(tweet *entity.ParsedTweet, sensitive bool) bool {
+	if len(d.rules) == 0 {
+		return true
+	}
+	for _, rule := range d.rules {
+		if rule.match(tweet, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *routingRule) match(tweet *entity.ParsedTweet, sensitive bool) bool {
+	if r.sensitive != nil && *r.sensitive != sensitive {
+		return false
+	}
+	if len(r.authors) > 0 && !r.matchAuthor(tweet) {
+		return false
+	}
+	if len(r.hashtags) > 0 && !r.matchHashtag(tweet) {
+		return false
+	}
+	if r.text != nil && !r.text.MatchString(tweet.FullText) {
+		return false
+	}
+	if r.media != nil && !r.matchMedia(tweet) {
+		return false
+	}
+	return true
+}
+
+// matchAuthor takes screen names as well as user ids.
+func (r *routingRule) matchAuthor(tweet *entity.ParsedTweet) bool {
+	for _, author := range r.authors {
+		author = strings.TrimPrefix(author, "@")
+		if strings.EqualFold(author, tweet.ParsedUser.ScreenName) || author == tweet.ParsedUser.UserId {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *routingRule) matchHashtag(tweet *entity.ParsedTweet) bool {
+	for _, hashtag := range tweet.Entities.Hashtags {
+		for _, h := range r.hashtags {
+			if strings.EqualFold(hashtag, h) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func (r *routingRule) matchMedia(tweet *entity.ParsedTweet) bool {
+	for _, media := range tweet.Entities.Media {
+		switch v := media.(type) {
+		case entity.ParsedMediaPhoto:
+			if r.media["photo"] {
+				return true
+			}
+		case entity.ParsedMediaVideo:
+			if v.IsAnimatedGif && r.media["gif"] || !v.IsAnimatedGif && r.media["video"] {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// allDestinations returns the main channel, the NSFW channel when there is
+// one and the configured destinations.
+func (bot *bot) allDestinations() []*destination {
+	destinations := []*destination{bot.main}
+	if bot.nsfw != nil {
+		destinations = append(destinations, bot.nsfw)
+	}
+	return append(destinations, bot.destinations...)
+}
+
+func (bot *bot) isChannel(chatID int64) bool {
+	for _, d := range bot.allDestinations() {
+		if d.channelChatID == chatID {
+			return true
+		}
+	}
+	return false
+}
+
+func (bot *bot) isGroup(chatID int64) bool {
+	for _, d := range bot.allDestinations() {
+		if d.groupChatID == chatID {
+			return true
+		}
+	}
+	return false
+}
+
+// route picks the destinations of a post. popular tells whether the tweet
+// reaches the given popularity the way the source judged it. spoiler is set
+// when sensitive posts stay in the channels they were routed to.
+func (bot *bot) route(tweet *entity.ParsedTweet, sensitive bool, popular func(popularity) bool) (destinations []*destination, spoiler bool) {
+	if sensitive {
+		switch bot.sensitive.mode {
+		case sensitiveDrop:
+			return nil, false
+		case sensitiveChannel:
+			return []*destination{bot.nsfw}, false
+		default:
+			spoiler = true
+		}
+	}
+	for _, d := range bot.destinations {
+		if !d.match(tweet, sensitive) {
+			continue
+		}
+		if d.popularity != nil && !popular(*d.popularity) {
+			continue
+		}
+		destinations = append(destinations, d)
+	}
+	if len(destinations) == 0 {
+		destinations = append(destinations, bot.main)
+	}
+	return destinations, spoiler
+}
+
+// isPosted tells whether the ledger has the tweet in the chat already.
+func (bot *bot) isPosted(tweetId string, chatID int64) (bool, error) {
+	id, err := strconv.ParseInt(tweetId, 10, 64)
+	if err != nil {
+		return false, err
+	}
+	return models.Posts(models.PostWhere.TweetID.EQ(id), models.PostWhere.ChatID.EQ(chatID)).Exists(context.Background(), bot.db)
+}
+
+// insertPost records the first message of a tweet posted to a chat.
+func (bot *bot) insertPost(tweetId string, chatID, messageID int64) error {
+	id, err := strconv.ParseInt(tweetId, 10, 64)
+	if err != nil {
+		return err
+	}
+	p := models.Post{
+		TweetID:       id,
+		ChatID:        chatID,
+		MessageID:     messageID,
+		Fingerprinted: bot.duplicateMode != duplicateOff,
+		CreatedAt:     time.Now(),
+		UpdatedAt:     time.Now(),
+	}
+	return p.Insert(context.Background(), bot.db, boil.Infer())
+}
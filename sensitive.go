@@ -0,0 +1,53 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/JasonKhew96/twiscraper/entity"
+)
+
+// What happens to sensitive tweets.
+const (
+	sensitiveSpoiler = "spoiler"
+	sensitiveChannel = "channel"
+	sensitiveDrop    = "drop"
+)
+
+// sensitiveFilter tells which tweets are sensitive, either flagged by twitter
+// or matching one of the configured keywords or hashtags.
+type sensitiveFilter struct {
+	mode     string
+	keywords []string
+	hashtags []string
+}
+
+func newSensitiveFilter(mode string, keywords, hashtags []string) *sensitiveFilter {
+	f := &sensitiveFilter{mode: mode}
+	for _, keyword := range keywords {
+		f.keywords = append(f.keywords, strings.ToLower(keyword))
+	}
+	for _, hashtag := range hashtags {
+		f.hashtags = append(f.hashtags, strings.TrimLeft(hashtag, "#＃"))
+	}
+	return f
+}
+
+func (f *sensitiveFilter) match(tweet *entity.ParsedTweet) bool {
+	if tweet.PossiblySensitive {
+		return true
+	}
+	text := strings.ToLower(tweet.FullText)
+	for _, keyword := range f.keywords {
+		if strings.Contains(text, keyword) {
+			return true
+		}
+	}
+	for _, hashtag := range tweet.Entities.Hashtags {
+		for _, h := range f.hashtags {
+			if strings.EqualFold(hashtag, h) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,292 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/JasonKhew96/twiscraper"
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/pkg/errors"
+)
+
+var errSessionExpired = errors.New("twitter session expired")
+
+// errAllAccountsBackedOff is returned when every logged in account is
+// backing off from earlier errors.
+var errAllAccountsBackedOff = errors.New("every twitter account is backing off")
+
+// isAuthError tells apart failures caused by expired or revoked cookies from
+// network and rate limit errors. twiscraper only wraps errors with %v, so the
+// messages are matched as text.
+func isAuthError(err error) bool {
+	if err == nil {
+		return false
+	}
+	if errors.Is(err, errSessionExpired) || errors.Is(err, twiscraper.ErrorNotLogined) {
+		return true
+	}
+	msg := strings.ToLower(err.Error())
+	for _, s := range []string{
+		fmt.Sprintf("%d %s", http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized)),
+		"could not authenticate you",
+		"bad authentication data",
+		twiscraper.ErrorNotLogined.Error(),
+	} {
+		if strings.Contains(msg, strings.ToLower(s)) {
+			return true
+		}
+	}
+	return false
+}
+
+func newScraper(cookie, xcsrfToken string) (*twiscraper.Scraper, error) {
+	return twiscraper.New(&twiscraper.ScraperOptions{
+		Delay:      3 * time.Second,
+		Cookie:     cookie,
+		XCsrfToken: xcsrfToken,
+		Timeout:    time.Minute,
+	})
+}
+
+// csrfTokenFromCookie returns the ct0 cookie, which twitter expects to be
+// echoed back as the x-csrf-token header.
+func csrfTokenFromCookie(cookie string) string {
+	for _, part := range strings.Split(cookie, ";") {
+		name, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if ok && name == "ct0" {
+			return value
+		}
+	}
+	return ""
+}
+
+// twitterAccount holds the scraper of one twitter session behind a lock so
+// the credentials can be replaced while the loop and the telegram handlers are
+// using it.
+type twitterAccount struct {
+	name string
+	// of the GraphQL operations twiscraper does not know
+	queryIds map[string]string
+
+	mu           sync.RWMutex
+	scraper      *twiscraper.Scraper
+	cookie       string
+	xcsrfToken   string
+	expiredAt    time.Time
+	backoffUntil time.Time
+}
+
+func newTwitterAccount(name, cookie, xcsrfToken string, queryIds map[string]string) (*twitterAccount, error) {
+	scraper, err := newScraper(cookie, xcsrfToken)
+	if err != nil {
+		return nil, err
+	}
+	return &twitterAccount{name: name, queryIds: queryIds, scraper: scraper, cookie: cookie, xcsrfToken: xcsrfToken}, nil
+}
+
+func (a *twitterAccount) get() *twiscraper.Scraper {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.scraper
+}
+
+func (a *twitterAccount) credentials() (cookie, xcsrfToken string) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.cookie, a.xcsrfToken
+}
+
+func (a *twitterAccount) replace(cookie, xcsrfToken string) error {
+	scraper, err := newScraper(cookie, xcsrfToken)
+	if err != nil {
+		return err
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.scraper = scraper
+	a.cookie = cookie
+	a.xcsrfToken = xcsrfToken
+	a.expiredAt = time.Time{}
+	a.backoffUntil = time.Time{}
+	return nil
+}
+
+// markExpired reports whether the session was healthy until now.
+func (a *twitterAccount) markExpired() bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	if !a.expiredAt.IsZero() {
+		return false
+	}
+	a.expiredAt = time.Now()
+	return true
+}
+
+func (a *twitterAccount) isExpired() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return !a.expiredAt.IsZero()
+}
+
+func (a *twitterAccount) backoff(d time.Duration) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.backoffUntil = time.Now().Add(d)
+}
+
+func (a *twitterAccount) isAvailable() bool {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.expiredAt.IsZero() && time.Now().After(a.backoffUntil)
+}
+
+func (a *twitterAccount) String() string {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	switch {
+	case !a.expiredAt.IsZero():
+		return "expired since " + formatTime(a.expiredAt)
+	case time.Now().Before(a.backoffUntil):
+		return "backing off until " + formatTime(a.backoffUntil)
+	}
+	return "logged in"
+}
+
+// accountPool hands out the twitter accounts in turn for timeline fetching,
+// while follow actions always go through the curator account.
+type accountPool struct {
+	mu       sync.Mutex
+	accounts []*twitterAccount
+	cursor   int
+	curator  *twitterAccount
+}
+
+func newAccountPool(configs []TwitterAccountConfig, curator string, queryIds map[string]string) (*accountPool, error) {
+	pool := &accountPool{}
+	for _, c := range configs {
+		account, err := newTwitterAccount(c.Name, c.Cookie, c.XCsrfToken, queryIds)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create twitter account %s", c.Name)
+		}
+		pool.accounts = append(pool.accounts, account)
+		if c.Name == curator {
+			pool.curator = account
+		}
+	}
+	if pool.curator == nil {
+		return nil, errors.Errorf("curator account %s does not exist", curator)
+	}
+	return pool, nil
+}
+
+// next returns the next available account, or nil when every account is
+// either logged out or backing off.
+func (p *accountPool) next() *twitterAccount {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	for range p.accounts {
+		account := p.accounts[p.cursor]
+		p.cursor = (p.cursor + 1) % len(p.accounts)
+		if account.isAvailable() {
+			return account
+		}
+	}
+	return nil
+}
+
+// any returns an available account, falling back to the curator.
+func (p *accountPool) any() *twitterAccount {
+	if account := p.next(); account != nil {
+		return account
+	}
+	return p.curator
+}
+
+func (p *accountPool) get(name string) *twitterAccount {
+	for _, account := range p.accounts {
+		if account.name == name {
+			return account
+		}
+	}
+	return nil
+}
+
+func (p *accountPool) allExpired() bool {
+	for _, account := range p.accounts {
+		if !account.isExpired() {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *accountPool) String() string {
+	var lines []string
+	for _, account := range p.accounts {
+		name := account.name
+		if account == p.curator {
+			name += " (curator)"
+		}
+		lines = append(lines, fmt.Sprintf("%s: %s", name, account))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// checkAuthError marks the account as expired and alerts the owner once when
+// err is an authentication failure.
+func (bot *bot) checkAuthError(account *twitterAccount, err error) bool {
+	if !isAuthError(err) {
+		return false
+	}
+	if account.markExpired() {
+		log.Println("twitter session expired", account.name, err)
+		bot.tg.SendMessage(bot.ownerID, fmt.Sprintf("Twitter session %s expired.\n\n%s\n\nSend /setcookie %s <cookie> in private chat to log in again.", account.name, err.Error(), account.name), nil)
+	}
+	return true
+}
+
+func (bot *bot) commandSetCookie(b *gotgbot.Bot, ctx *ext.Context) error {
+	if ctx.EffectiveUser.Id != bot.ownerID || ctx.EffectiveChat.Type != gotgbot.ChatTypePrivate {
+		return nil
+	}
+	s := strings.SplitN(strings.TrimSpace(ctx.EffectiveMessage.Text), " ", 3)
+	if len(s) < 2 {
+		_, err := ctx.EffectiveMessage.Reply(b, "Invalid command format.\nUsage:\n/setcookie <cookie>\n/setcookie <account> <cookie>", nil)
+		return err
+	}
+
+	// the message holds the credentials, do not leave it in the chat history
+	if _, err := ctx.EffectiveMessage.Delete(b, nil); err != nil {
+		log.Println(err)
+	}
+
+	account := bot.accounts.curator
+	cookie := strings.TrimSpace(strings.Join(s[1:], " "))
+	if len(s) == 3 && !strings.Contains(s[1], "=") {
+		account = bot.accounts.get(s[1])
+		if account == nil {
+			_, err := b.SendMessage(ctx.EffectiveChat.Id, fmt.Sprintf("Unknown account %s", s[1]), nil)
+			return err
+		}
+		cookie = strings.TrimSpace(s[2])
+	}
+
+	xcsrfToken := csrfTokenFromCookie(cookie)
+	if xcsrfToken == "" {
+		_, err := b.SendMessage(ctx.EffectiveChat.Id, "Cookie has no ct0 value", nil)
+		return err
+	}
+	if err := account.replace(cookie, xcsrfToken); err != nil {
+		_, err = b.SendMessage(ctx.EffectiveChat.Id, fmt.Sprintf("Error %s", err.Error()), nil)
+		return err
+	}
+	log.Println("twitter session replaced", account.name)
+
+	_, err := b.SendMessage(ctx.EffectiveChat.Id, fmt.Sprintf("Twitter session %s updated", account.name), nil)
+	return err
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/JasonKhew96/twiscraper"
+	"github.com/pkg/errors"
+)
+
+type timelineFunc func(ctx context.Context, account *twitterAccount) <-chan *twiscraper.TimelineResult
+
+// timelineSource is one timeline read by the loop. The home timelines are
+// read on every loop, the sources from the config file at their own interval.
+type timelineSource struct {
+	name   string
+	sorted bool
+	// every account has a home timeline of its own
+	perAccount bool
+	// whether the curator follows the illustrators it finds here
+	follow     bool
+	interval   time.Duration
+	popularity popularity
+	fetch      timelineFunc
+
+	// only touched by the loop
+	lastRun time.Time
+}
+
+func (s *timelineSource) isDue() bool {
+	return time.Since(s.lastRun) >= s.interval
+}
+
+// cursorName is the name the high-water mark of the timeline is kept under
+// when the account reads it. What one account has seen of its home timeline
+// says nothing of the home timeline of another.
+func (s *timelineSource) cursorName(account *twitterAccount) string {
+	if s.perAccount {
+		return account.name + "/" + s.name
+	}
+	return s.name
+}
+
+func newTimelineSources(config *Config) ([]*timelineSource, error) {
+	home := popularity{
+		tweetFactor:   config.PopularTweetFactor,
+		retweetFactor: config.PopularRetweetFactor,
+		quoteFactor:   config.PopularQuoteFactor,
+	}
+	sources := []*timelineSource{
+		{
+			name:       "GetHomeTimeline",
+			perAccount: true,
+			follow:     true,
+			popularity: home,
+			fetch: func(ctx context.Context, account *twitterAccount) <-chan *twiscraper.TimelineResult {
+				return account.get().GetHomeTimeline(ctx, 5*20)
+			},
+		},
+		{
+			name:       "GetHomeLatestTimeline",
+			sorted:     true,
+			perAccount: true,
+			follow:     true,
+			popularity: home,
+			fetch: func(ctx context.Context, account *twitterAccount) <-chan *twiscraper.TimelineResult {
+				return account.get().GetHomeLatestTimeline(ctx, 5*20)
+			},
+		},
+	}
+
+	for _, c := range config.Sources {
+		target := c.Target
+		count := c.Pages * 20
+		source := &timelineSource{
+			name:     c.Name,
+			sorted:   true,
+			interval: c.Interval,
+			follow:   c.Follow,
+			popularity: popularity{
+				tweetFactor:   c.PopularTweetFactor,
+				retweetFactor: c.PopularRetweetFactor,
+				quoteFactor:   c.PopularQuoteFactor,
+			},
+		}
+		switch c.Type {
+		case "list":
+			source.fetch = func(ctx context.Context, account *twitterAccount) <-chan *twiscraper.TimelineResult {
+				return account.getListTimeline(ctx, target, count)
+			}
+		case "search":
+			source.fetch = func(ctx context.Context, account *twitterAccount) <-chan *twiscraper.TimelineResult {
+				return account.getSearchTimeline(ctx, target, count)
+			}
+		case "media":
+			source.fetch = func(ctx context.Context, account *twitterAccount) <-chan *twiscraper.TimelineResult {
+				return account.get().GetTimelineMedia(ctx, target, count)
+			}
+		default:
+			return nil, errors.Errorf("source %s has unknown type %s", c.Name, c.Type)
+		}
+		sources = append(sources, source)
+	}
+	return sources, nil
+}
@@ -0,0 +1,188 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+)
+
+type pauseTarget int
+
+const (
+	pauseScrape pauseTarget = 1 << iota
+	pausePublish
+
+	pauseAll = pauseScrape | pausePublish
+)
+
+func parsePauseTarget(s string) (pauseTarget, error) {
+	switch strings.ToLower(s) {
+	case "scrape":
+		return pauseScrape, nil
+	case "publish":
+		return pausePublish, nil
+	case "all", "":
+		return pauseAll, nil
+	}
+	return 0, fmt.Errorf("unknown target %q", s)
+}
+
+// runtimeState is shared between the scrape loop, the publish worker and the
+// telegram handlers, so every field is guarded by mu.
+type runtimeState struct {
+	mu sync.Mutex
+
+	paused pauseTarget
+
+	startedAt     time.Time
+	lastLoopAt    time.Time
+	lastLoopCount int
+	nextLoopAt    time.Time
+
+	lastTimelineAt  time.Time
+	lastTimelineErr error
+}
+
+func (s *runtimeState) isPaused(target pauseTarget) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.paused&target != 0
+}
+
+func (s *runtimeState) pause(target pauseTarget) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.paused |= target
+}
+
+func (s *runtimeState) resume(target pauseTarget) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.paused &^= target
+}
+
+func (s *runtimeState) setLoopResult(count int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastLoopAt = time.Now()
+	s.lastLoopCount = count
+}
+
+func (s *runtimeState) setNextLoop(t time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.nextLoopAt = t
+}
+
+func (s *runtimeState) setTimelineResult(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.lastTimelineAt = time.Now()
+	s.lastTimelineErr = err
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return "never"
+	}
+	return t.Format("2006-01-02 15:04:05")
+}
+
+func (bot *bot) statusText() string {
+	bot.cachesMu.Lock()
+	cacheSize := len(bot.caches)
+	bot.cachesMu.Unlock()
+
+	s := &bot.state
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	running := func(target pauseTarget) string {
+		if s.paused&target != 0 {
+			return "paused"
+		}
+		return "running"
+	}
+
+	session := "unknown"
+	if s.lastTimelineErr != nil {
+		session = fmt.Sprintf("failing since %s: %s", formatTime(s.lastTimelineAt), s.lastTimelineErr.Error())
+	} else if !s.lastTimelineAt.IsZero() {
+		session = fmt.Sprintf("healthy (%s)", formatTime(s.lastTimelineAt))
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "Scrape: %s\n", running(pauseScrape))
+	fmt.Fprintf(&sb, "Publish: %s\n", running(pausePublish))
+	fmt.Fprintf(&sb, "Last loop: %s (%d new)\n", formatTime(s.lastLoopAt), s.lastLoopCount)
+	fmt.Fprintf(&sb, "Next loop: %s\n", formatTime(s.nextLoopAt))
+	fmt.Fprintf(&sb, "Error count: %d\n", bot.errCount.Load())
+	fmt.Fprintf(&sb, "Circuit breaker: %s\n", bot.breaker)
+	fmt.Fprintf(&sb, "Queue: %d\n", len(bot.jobs))
+	fmt.Fprintf(&sb, "Cache: %d\n", cacheSize)
+	fmt.Fprintf(&sb, "Uptime: %s\n", time.Since(s.startedAt).Truncate(time.Second))
+	fmt.Fprintf(&sb, "Timeline: %s\n", session)
+	fmt.Fprintf(&sb, "Twitter accounts:\n%s", bot.accounts)
+	return sb.String()
+}
+
+func (bot *bot) commandPause(b *gotgbot.Bot, ctx *ext.Context) error {
+	if ctx.EffectiveUser.Id != bot.ownerID {
+		return nil
+	}
+	s := strings.Fields(ctx.EffectiveMessage.Text)
+	if len(s) != 2 {
+		_, err := ctx.EffectiveMessage.Reply(b, "Invalid command format.\nUsage:\n/pause scrape\n/pause publish\n/pause all", nil)
+		return err
+	}
+	log.Println(ctx.EffectiveMessage.Text)
+
+	target, err := parsePauseTarget(s[1])
+	if err != nil {
+		_, err = ctx.EffectiveMessage.Reply(b, err.Error(), nil)
+		return err
+	}
+	bot.state.pause(target)
+
+	_, err = ctx.EffectiveMessage.Reply(b, bot.statusText(), nil)
+	return err
+}
+
+func (bot *bot) commandResume(b *gotgbot.Bot, ctx *ext.Context) error {
+	if ctx.EffectiveUser.Id != bot.ownerID {
+		return nil
+	}
+	s := strings.Fields(ctx.EffectiveMessage.Text)
+	if len(s) > 2 {
+		_, err := ctx.EffectiveMessage.Reply(b, "Invalid command format.\nUsage:\n/resume\n/resume scrape\n/resume publish", nil)
+		return err
+	}
+	log.Println(ctx.EffectiveMessage.Text)
+
+	arg := ""
+	if len(s) == 2 {
+		arg = s[1]
+	}
+	target, err := parsePauseTarget(arg)
+	if err != nil {
+		_, err = ctx.EffectiveMessage.Reply(b, err.Error(), nil)
+		return err
+	}
+	bot.state.resume(target)
+
+	_, err = ctx.EffectiveMessage.Reply(b, bot.statusText(), nil)
+	return err
+}
+
+func (bot *bot) commandStatus(b *gotgbot.Bot, ctx *ext.Context) error {
+	if ctx.EffectiveUser.Id != bot.ownerID {
+		return nil
+	}
+	_, err := ctx.EffectiveMessage.Reply(b, bot.statusText(), nil)
+	return err
+}
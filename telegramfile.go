@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// openTelegramFile opens the file of the file id. A local Bot API server
+// hands out the path of the file on the disk it shares with the bot, the
+// official one a path to download from. Downloads are spooled to a
+// temporary file that goes once the returned file is closed.
+func (bot *bot) openTelegramFile(fileId string) (io.ReadCloser, error) {
+	botFile, err := bot.tg.GetFile(fileId, nil)
+	if err != nil {
+		return nil, err
+	}
+	if filepath.IsAbs(botFile.FilePath) {
+		if f, err := os.Open(botFile.FilePath); err == nil {
+			return f, nil
+		}
+	}
+
+	// the url is of BOT_API_URL when it is set, the bot client defaults to it
+	resp, err := bot.fetcher.client.Get(botFile.URL(bot.tg, nil))
+	if err != nil {
+		// the error has the url in it, and the url has the token
+		return nil, errors.New("failed to download telegram file")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to download telegram file %s: %s", botFile.FilePath, resp.Status)
+	}
+	if resp.ContentLength > bot.fetcher.limit {
+		return nil, &FileTooLargeError{}
+	}
+
+	tmp, err := bot.fetcher.cache.tempFile()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create temp file")
+	}
+	n, err := io.Copy(tmp, io.LimitReader(resp.Body, bot.fetcher.limit+1))
+	if err == nil && n > bot.fetcher.limit {
+		err = &FileTooLargeError{}
+	}
+	if err == nil {
+		_, err = tmp.Seek(0, io.SeekStart)
+	}
+	if err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return nil, err
+	}
+	return &tempFile{tmp}, nil
+}
+
+// tempFile is removed once it is closed.
+type tempFile struct {
+	*os.File
+}
+
+func (f *tempFile) Close() error {
+	err := f.File.Close()
+	os.Remove(f.Name())
+	return err
+}
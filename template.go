@@ -0,0 +1,196 @@
+package main
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/JasonKhew96/twiscraper/entity"
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/PaulSonOfLars/gotgbot/v2/ext"
+	"github.com/pkg/errors"
+)
+
+// defaultCaptionTemplate is the layout captions had before templates.
+var defaultCaptionTemplate = template.Must(parseCaptionTemplate("default", "{{.Text}}\n\n{{.URL}}"))
+
+// Parts of the caption that carry entities are handed to the template as
+// private use characters and swapped for the real text afterwards.
+const (
+	placeholderText rune = '\uE000' + iota
+	placeholderHashtags
+	placeholderURL
+	placeholderAuthor
+)
+
+// captionData is what a caption template can use.
+type captionData struct {
+	// twiscraper does not parse display names, Name is the screen name
+	Name       string
+	ScreenName string
+	Author     string
+	Text       string
+	Hashtags   string
+	URL        string
+	Likes      int
+	Retweets   int
+	Views      int
+	Timestamp  time.Time
+	MediaCount int
+}
+
+type captionTemplates struct {
+	fallback *template.Template
+	chats    map[int64]*template.Template
+}
+
+func parseCaptionTemplate(name, text string) (*template.Template, error) {
+	return template.New(name).Parse(text)
+}
+
+// newCaptionTemplates parses the templates from the config, keyed by chat id
+// or "default".
+func newCaptionTemplates(texts map[string]string) (*captionTemplates, error) {
+	t := &captionTemplates{
+		fallback: defaultCaptionTemplate,
+		chats:    make(map[int64]*template.Template),
+	}
+	for key, text := range texts {
+		tmpl, err := parseCaptionTemplate(key, text)
+		if err != nil {
+			return nil, errors.Wrapf(err, "caption template %s is invalid", key)
+		}
+		if key == "default" {
+			t.fallback = tmpl
+			continue
+		}
+		chatID, err := strconv.ParseInt(key, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "caption template %s is not a chat id", key)
+		}
+		t.chats[chatID] = tmpl
+	}
+	return t, nil
+}
+
+func (t *captionTemplates) get(chatID int64) *template.Template {
+	if tmpl, ok := t.chats[chatID]; ok {
+		return tmpl
+	}
+	return t.fallback
+}
+
+// renderCaption runs the template and returns a function that fills the
+// placeholders in, taking the tweet text to use.
+func renderCaption(tmpl *template.Template, tweet *entity.ParsedTweet, mediaCount int) (func(text richText) richText, error) {
+	var hashtags, link, author richText
+	seen := make(map[string]bool)
+	for _, hashtag := range tweet.Entities.Hashtags {
+		if seen[hashtag] {
+			continue
+		}
+		seen[hashtag] = true
+		if hashtags.len() > 0 {
+			hashtags.write(" ")
+		}
+		hashtags.link("#"+hashtag, "https://x.com/hashtag/"+url.QueryEscape(hashtag))
+	}
+	link.url(tweet.Url)
+	author.link("@"+tweet.ParsedUser.ScreenName, "https://x.com/"+tweet.ParsedUser.ScreenName)
+
+	var sb strings.Builder
+	if err := tmpl.Execute(&sb, captionData{
+		Name:       tweet.ParsedUser.ScreenName,
+		ScreenName: tweet.ParsedUser.ScreenName,
+		Author:     string(placeholderAuthor),
+		Text:       string(placeholderText),
+		Hashtags:   string(placeholderHashtags),
+		URL:        string(placeholderURL),
+		Likes:      tweet.FavouriteCount,
+		Retweets:   tweet.RetweetedCount,
+		Views:      tweet.Views,
+		Timestamp:  tweet.CreatedAt,
+		MediaCount: mediaCount,
+	}); err != nil {
+		return nil, err
+	}
+	rendered := sb.String()
+
+	return func(text richText) richText {
+		var out richText
+		for _, r := range rendered {
+			switch r {
+			case placeholderText:
+				out.append(text)
+			case placeholderHashtags:
+				out.append(hashtags)
+			case placeholderURL:
+				out.append(link)
+			case placeholderAuthor:
+				out.append(author)
+			default:
+				out.write(string(r))
+			}
+		}
+		return out
+	}, nil
+}
+
+func (bot *bot) commandPreview(b *gotgbot.Bot, ctx *ext.Context) error {
+	if ctx.EffectiveUser.Id != bot.ownerID {
+		return nil
+	}
+	// the first line holds the arguments, the following lines an optional
+	// template to try out
+	args, text, _ := strings.Cut(ctx.EffectiveMessage.Text, "\n")
+	s := strings.Fields(args)
+	if len(s) < 2 || len(s) > 3 {
+		_, err := ctx.EffectiveMessage.Reply(b, "Invalid command format.\nUsage:\n/preview <url> [chat id]\n/preview <url>\n<template>", nil)
+		return err
+	}
+
+	twitterUrl, err := parseTwitterUrl(s[1])
+	if err != nil || twitterUrl.TweetID == "" {
+		_, err := ctx.EffectiveMessage.Reply(b, "Invalid tweet url", nil)
+		return err
+	}
+
+	tmpl := bot.captionTemplates.get(bot.channelChatID)
+	if len(s) == 3 {
+		chatID, err := strconv.ParseInt(s[2], 10, 64)
+		if err != nil {
+			_, err := ctx.EffectiveMessage.Reply(b, "Invalid chat id", nil)
+			return err
+		}
+		tmpl = bot.captionTemplates.get(chatID)
+	}
+	if strings.TrimSpace(text) != "" {
+		tmpl, err = parseCaptionTemplate("preview", text)
+		if err != nil {
+			_, err := ctx.EffectiveMessage.Reply(b, fmt.Sprintf("Error %s", err.Error()), nil)
+			return err
+		}
+	}
+
+	tweet, err := bot.accounts.any().get().GetTweetDetail(twitterUrl.TweetID)
+	if err != nil {
+		_, err := ctx.EffectiveMessage.Reply(b, fmt.Sprintf("Error GetTweetDetail %s", err.Error()), nil)
+		return err
+	}
+	if _, err := renderCaption(tmpl, tweet, len(tweet.Entities.Media)); err != nil {
+		_, err := ctx.EffectiveMessage.Reply(b, fmt.Sprintf("Error %s", err.Error()), nil)
+		return err
+	}
+
+	caption, _ := tweet2Caption(tweet, tmpl, richText{})
+	_, err = ctx.EffectiveMessage.Reply(b, caption.text, &gotgbot.SendMessageOpts{
+		Entities: caption.entities,
+		LinkPreviewOptions: &gotgbot.LinkPreviewOptions{
+			IsDisabled: true,
+		},
+	})
+	return err
+}
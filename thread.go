@@ -0,0 +1,175 @@
+package main
+
+import (
+	"log"
+	"strconv"
+
+	"github.com/JasonKhew96/twiscraper/entity"
+)
+
+// selfThread returns the replies the author chained under their own tweet,
+// like the following pages of a comic. Twitter puts that chain into the first
+// conversation module of the tweet detail.
+func selfThread(tweet *entity.ParsedTweet) []*entity.ParsedTweet {
+	for _, innerReplies := range tweet.Replies {
+		if len(innerReplies) == 0 || innerReplies[0].ParsedUser.UserId != tweet.ParsedUser.UserId {
+			continue
+		}
+		var thread []*entity.ParsedTweet
+		for _, reply := range innerReplies {
+			if reply.ParsedUser.UserId != tweet.ParsedUser.UserId {
+				break
+			}
+			thread = append(thread, reply)
+		}
+		return thread
+	}
+	return nil
+}
+
+// getThread returns the tweet followed by the self-replies that carry media.
+// Only tweets with replies are looked up, and not even those when the
+// timeline brought the replies along. Failing to load the replies only costs
+// the merge, so the tweet is posted alone then.
+func (bot *bot) getThread(tweet *entity.ParsedTweet) []*entity.ParsedTweet {
+	posts := []*entity.ParsedTweet{tweet}
+	if tweet.ReplyCount == 0 {
+		return posts
+	}
+	thread := selfThread(tweet)
+	if len(tweet.Replies) == 0 {
+		account := bot.accounts.any()
+		detail, err := account.get().GetTweetDetail(tweet.TweetId)
+		if err != nil {
+			log.Println("GetTweetDetail error", tweet.Url, err)
+			bot.checkAuthError(account, err)
+			return posts
+		}
+		thread = selfThread(detail)
+	}
+	for _, reply := range thread {
+		if isMedia(*reply) {
+			posts = append(posts, reply)
+		}
+	}
+	return posts
+}
+
+func (bot *bot) isSensitive(posts []*entity.ParsedTweet) bool {
+	for _, post := range posts {
+		if bot.sensitive.match(post) {
+			return true
+		}
+	}
+	return false
+}
+
+// maxThreadLinks is the most posts of a thread the caption links to.
+const maxThreadLinks = 20
+
+// threadLinks links the posts of the thread by their number.
+func threadLinks(posts []*entity.ParsedTweet) richText {
+	var links richText
+	for i, post := range posts {
+		// long threads link the first posts and the last one
+		if i >= maxThreadLinks-1 && i < len(posts)-1 {
+			if i == maxThreadLinks-1 {
+				links.write(" …")
+			}
+			continue
+		}
+		if i > 0 {
+			links.write(" ")
+		}
+		links.link(strconv.Itoa(i+1), post.Url)
+	}
+	return links
+}
+
+// publishTweet queues the tweet for the channels it is routed to. Media of a
+// self-reply thread is merged into the post, the worker splits it into albums
+// as needed. quote is the tweet that quoted it, credited in the caption.
+// popular judges the popularity of the destinations that have their own.
+func (bot *bot) publishTweet(tweet *entity.ParsedTweet, quote *entity.ParsedTweet, popular func(popularity) bool) {
+	posts := bot.getThread(tweet)
+
+	merged := *tweet
+	merged.Entities.Media = nil
+	for _, post := range posts {
+		merged.Entities.Media = append(merged.Entities.Media, post.Entities.Media...)
+	}
+
+	// keep the following pages from being posted on their own later
+	for _, post := range posts[1:] {
+		id, err := strconv.ParseInt(post.TweetId, 10, 64)
+		if err != nil {
+			continue
+		}
+		if d, err := bot.getTweetById(id); err == nil && d != nil {
+			continue
+		}
+		if err := bot.insertTweet(post); err != nil {
+			log.Println("insertTweet error", post.Url, err)
+		}
+	}
+
+	destinations, spoiler := bot.route(&merged, bot.isSensitive(posts), popular)
+	if len(destinations) == 0 {
+		log.Println("drop sensitive", tweet.Url)
+		return
+	}
+
+	fingerprints := bot.fingerprintPhotos(&merged)
+
+	var footer richText
+	if len(posts) > 1 {
+		footer.write("\n\n")
+		footer.append(threadLinks(posts))
+	}
+	if quote != nil {
+		footer.write("\n\nQuoted by ")
+		footer.link("@"+quote.ParsedUser.ScreenName, quote.Url)
+	}
+
+	positions := make([]int, len(merged.Entities.Media))
+	for i := range positions {
+		positions[i] = i + 1
+	}
+	cache := &twiCache{
+		tweet:     tweet,
+		username:  tweet.ParsedUser.ScreenName,
+		tweetId:   tweet.TweetId,
+		medias:    merged.Entities.Media,
+		positions: positions,
+	}
+	queued := false
+	for _, d := range destinations {
+		if posted, err := bot.isPosted(tweet.TweetId, d.channelChatID); err != nil {
+			log.Println("isPosted error", tweet.Url, err)
+		} else if posted {
+			continue
+		}
+		if duplicates, all := bot.findDuplicates(d.channelChatID, fingerprints); len(duplicates) > 0 {
+			skip := all && bot.duplicateMode == duplicateSkip
+			bot.notifyDuplicates(tweet, d, duplicates, skip)
+			if skip {
+				continue
+			}
+		}
+		caption, followUps := tweet2Caption(&merged, bot.captionTemplates.get(d.channelChatID), footer)
+		inputMedias, files := tweet2InputMedias(&merged, caption, spoiler)
+		setMediaSources(files, posts)
+		bot.jobs <- Job{
+			chatID:       d.channelChatID,
+			inputMedias:  inputMedias,
+			files:        files,
+			followUps:    followUps,
+			fingerprints: fingerprints,
+			cache:        cache,
+		}
+		queued = true
+	}
+	if queued && bot.archive != nil {
+		go bot.archiveOriginals(cache)
+	}
+}
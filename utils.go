@@ -1,32 +1,16 @@
 package main
 
 import (
-	"bytes"
 	"fmt"
-	"log"
-	"net/http"
 	"net/url"
 	"regexp"
 	"strings"
-	"time"
 
 	"github.com/JasonKhew96/twiscraper/entity"
 	"github.com/PaulSonOfLars/gotgbot/v2"
 	"github.com/pkg/errors"
 )
 
-var allMdV2 = []string{"_", "*", "[", "]", "(", ")", "~", "`", ">", "#", "+", "-", "=", "|", "{", "}", ".", "!", "\\"}
-var mdV2Repl = strings.NewReplacer(func() (out []string) {
-	for _, x := range allMdV2 {
-		out = append(out, x, "\\"+x)
-	}
-	return out
-}()...)
-
-func EscapeMarkdownV2(s string) string {
-	return mdV2Repl.Replace(s)
-}
-
 type TwitterUrl struct {
 	Username string
 	UserID   string
@@ -97,100 +81,102 @@ func parseTwitterUrl(rawText string) (*TwitterUrl, error) {
 	return nil, errors.New("url is not a twitter url")
 }
 
-func tweet2Caption(tweet *entity.ParsedTweet) string {
-	caption := fmt.Sprintf("%s\n\n%s", EscapeMarkdownV2(strings.ReplaceAll(tweet.FullText, "＃", "#")), EscapeMarkdownV2(tweet.Url))
-	for _, mention := range tweet.Entities.UserMentions {
-		caption = strings.Replace(caption, "@"+EscapeMarkdownV2(mention.ScreenName), fmt.Sprintf(`[@%s](https://x\.com/%s)`, EscapeMarkdownV2(mention.ScreenName), EscapeMarkdownV2(mention.ScreenName)), 1)
-	}
-	for _, hashtag := range tweet.Entities.Hashtags {
-		caption = strings.Replace(caption, "\\#"+EscapeMarkdownV2(hashtag), fmt.Sprintf(`[\#%s](https://x\.com/hashtag/%s)`, EscapeMarkdownV2(hashtag), EscapeMarkdownV2(url.QueryEscape(hashtag))), 1)
-	}
-	return caption
+// mediaFileOf returns where to fetch the media and the name to upload it as.
+// size picks the variant of photos, like large or orig.
+func mediaFileOf(media entity.ParsedMedia, tweetId string, position int, size string) mediaFile {
+	var link string
+	switch v := media.(type) {
+	case entity.ParsedMediaPhoto:
+		link = v.Url
+	case entity.ParsedMediaVideo:
+		link = v.Url
+	}
+	newUrl := clearUrlQueries(link)
+	splits := strings.Split(newUrl, ".")
+	ext := splits[len(splits)-1]
+	file := mediaFile{
+		url:  newUrl,
+		name: fmt.Sprintf("%s_%02d.%s", tweetId, position, ext),
+	}
+	if _, ok := media.(entity.ParsedMediaPhoto); ok && (ext == "jpg" || ext == "jpeg" || ext == "png") {
+		file.url = strings.TrimSuffix(newUrl, "."+ext) + "?format=" + ext + "&name=" + size
+	}
+	return file
 }
 
-func tweet2InputMedias(tweet *entity.ParsedTweet, caption string) []gotgbot.InputMedia {
+// tweet2InputMedias returns the input medias of the tweet and the files they
+// are sent from, the files are attached when sending. The caption goes on the
+// first item. spoiler hides every item behind Telegram's spoiler animation.
+func tweet2InputMedias(tweet *entity.ParsedTweet, caption richText, spoiler bool) ([]gotgbot.InputMedia, []mediaFile) {
 	inputMedia := []gotgbot.InputMedia{}
-	if len(tweet.Entities.Media) > 0 {
-		for index, media := range tweet.Entities.Media {
-			switch v := media.(type) {
-			case entity.ParsedMediaPhoto:
-				if v.AltText != "" {
-					caption += fmt.Sprintf("\n\n\\[%d\\] %s", index+1, EscapeMarkdownV2(v.AltText))
-				}
-			case entity.ParsedMediaVideo:
-				if v.AltText != "" {
-					caption += fmt.Sprintf("\n\n\\[%d\\] %s", index+1, EscapeMarkdownV2(v.AltText))
-				}
-			}
+	var files []mediaFile
+	for i, media := range tweet.Entities.Media {
+		var c richText
+		if len(inputMedia) == 0 {
+			c = caption
 		}
-		for i, media := range tweet.Entities.Media {
-			c := ""
-			if len(inputMedia) == 0 {
-				c = caption
-			}
-			var fn string
-			switch v := media.(type) {
-			case entity.ParsedMediaPhoto:
-				newUrl := clearUrlQueries(v.Url)
-				splits := strings.Split(newUrl, ".")
-				ext := splits[len(splits)-1]
-				fn = fmt.Sprintf("%s_%02d.%s", tweet.TweetId, i+1, ext)
-				if ext == "jpg" || ext == "jpeg" || ext == "png" {
-					newUrl = strings.TrimSuffix(newUrl, "."+ext) + "?format=" + ext + "&name=large"
-				}
-				var media gotgbot.InputFileOrString
-				buf, err := downloadToBuffer(newUrl, fn)
-				if err != nil {
-					log.Println(err)
-					media = gotgbot.InputFileByURL(newUrl)
-				} else {
-					media = buf
-				}
-				inputMedia = append(inputMedia, gotgbot.InputMediaPhoto{
-					Media:     media,
-					Caption:   c,
-					ParseMode: "MarkdownV2",
+		switch v := media.(type) {
+		case entity.ParsedMediaPhoto:
+			files = append(files, mediaFileOf(media, tweet.TweetId, i+1, "large"))
+			inputMedia = append(inputMedia, gotgbot.InputMediaPhoto{
+				Caption:         c.text,
+				CaptionEntities: c.entities,
+				HasSpoiler:      spoiler,
+			})
+		case entity.ParsedMediaVideo:
+			file := mediaFileOf(media, tweet.TweetId, i+1, "large")
+			file.tweetId = tweet.TweetId
+			file.tweetUrl = tweet.Url
+			file.thumbUrl = v.ThumbUrl
+			files = append(files, file)
+			width := int64(v.Width)
+			height := int64(v.Height)
+			duration := int64(v.DurationMs / 1000)
+			if len(tweet.Entities.Media) == 1 && v.IsAnimatedGif {
+				inputMedia = append(inputMedia, gotgbot.InputMediaAnimation{
+					Caption:         c.text,
+					CaptionEntities: c.entities,
+					Width:           width,
+					Height:          height,
+					Duration:        duration,
+					HasSpoiler:      spoiler,
+				})
+			} else {
+				inputMedia = append(inputMedia, gotgbot.InputMediaVideo{
+					Cover:           v.ThumbUrl,
+					Caption:         c.text,
+					CaptionEntities: c.entities,
+					Width:           width,
+					Height:          height,
+					Duration:        duration,
+					HasSpoiler:      spoiler,
 				})
-			case entity.ParsedMediaVideo:
-				newUrl := clearUrlQueries(v.Url)
-				splits := strings.Split(newUrl, ".")
-				ext := splits[len(splits)-1]
-				fn = fmt.Sprintf("%s_%02d.%s", tweet.TweetId, i+1, ext)
-				var media gotgbot.InputFileOrString
-				buf, err := downloadToBuffer(newUrl, fn)
-				if err != nil {
-					log.Println(err)
-					media = gotgbot.InputFileByURL(newUrl)
-				} else {
-					media = buf
-				}
-				width := int64(v.Width)
-				height := int64(v.Height)
-				duration := int64(v.DurationMs / 1000)
-				if len(tweet.Entities.Media) == 1 && v.IsAnimatedGif {
-					inputMedia = append(inputMedia, gotgbot.InputMediaAnimation{
-						Media:     media,
-						Caption:   c,
-						Width:     width,
-						Height:    height,
-						Duration:  duration,
-						ParseMode: "MarkdownV2",
-					})
-				} else {
-					inputMedia = append(inputMedia, gotgbot.InputMediaVideo{
-						Media:     media,
-						Cover:     v.ThumbUrl,
-						Caption:   c,
-						Width:     width,
-						Height:    height,
-						Duration:  duration,
-						ParseMode: "MarkdownV2",
-					})
-				}
 			}
 		}
 	}
-	return inputMedia
+	return inputMedia, files
+}
+
+// originalDocuments returns the media in full size as documents captioned
+// with their urls, and the files they are sent from. positions numbers the
+// files, nil numbers them in order.
+func originalDocuments(tweetId string, medias []entity.ParsedMedia, positions []int) ([]gotgbot.InputMedia, []mediaFile) {
+	files := make([]mediaFile, len(medias))
+	for i, media := range medias {
+		position := i + 1
+		if positions != nil {
+			position = positions[i]
+		}
+		files[i] = mediaFileOf(media, tweetId, position, "orig")
+	}
+
+	var inputMedia []gotgbot.InputMedia
+	for _, file := range files {
+		inputMedia = append(inputMedia, gotgbot.InputMediaDocument{
+			Caption: file.url,
+		})
+	}
+	return inputMedia, files
 }
 
 func clearUrlQueries(link string) string {
@@ -201,35 +187,3 @@ func clearUrlQueries(link string) string {
 	}
 	return newUrl
 }
-
-type FileTooLargeError struct{}
-
-func (e *FileTooLargeError) Error() string {
-	return "file too large"
-}
-
-func downloadToBuffer(url, fn string) (gotgbot.InputFile, error) {
-	defaultClient := &http.Client{
-		Timeout: 15 * time.Second,
-	}
-	resp, err := defaultClient.Get(url)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to download file")
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, errors.New("failed to download file")
-	}
-
-	if resp.ContentLength > 50*1024*1024 {
-		return nil, &FileTooLargeError{}
-	}
-
-	buf := new(bytes.Buffer)
-	if _, err := buf.ReadFrom(resp.Body); err != nil {
-		return nil, errors.Wrap(err, "failed to read file")
-	}
-
-	return gotgbot.InputFileByReader(fn, buf), nil
-}
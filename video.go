@@ -0,0 +1,170 @@
+package main
+
+import (
+	"context"
+	"log"
+	"path/filepath"
+	"strings"
+
+	"github.com/JasonKhew96/twiscraper/entity"
+	"github.com/PaulSonOfLars/gotgbot/v2"
+	"github.com/pkg/errors"
+)
+
+func (bot *bot) checkVideos(inputMedias []gotgbot.InputMedia, files []mediaFile, variants videoVariants) ([]gotgbot.InputMedia, []mediaFile) {
+	checked := make([]gotgbot.InputMedia, len(inputMedias))
+	checkedFiles := make([]mediaFile, len(files))
+	copy(checked, inputMedias)
+	copy(checkedFiles, files)
+	for i, inputMedia := range inputMedias {
+		variant := mediaVariant(inputMedia)
+		if variant != "video" && variant != "animation" {
+			continue
+		}
+		if fileID, err := bot.getFileID(files[i].url, variant); err == nil && fileID != "" {
+			continue
+		}
+		f, _, err := bot.fetcher.fetch(files[i].url)
+		if err == nil {
+			f.Close()
+			continue
+		}
+		var tooLarge *FileTooLargeError
+		if !errors.As(err, &tooLarge) {
+			continue
+		}
+		stepped := false
+		for _, url := range bot.lowerVariants(files[i], variants) {
+			if bot.fitsUpload(url, variant) {
+				log.Println("step down video", files[i].url, url)
+				checkedFiles[i].url = url
+				stepped = true
+				break
+			}
+		}
+		if stepped || files[i].thumbUrl == "" {
+			continue
+		}
+		log.Println("send video thumbnail", files[i].url)
+		checked[i], checkedFiles[i] = videoThumbnail(inputMedia, files[i])
+	}
+	return checked, checkedFiles
+}
+
+func (bot *bot) fitsUpload(url, variant string) bool {
+	if fileID, err := bot.getFileID(url, variant); err == nil && fileID != "" {
+		return true
+	}
+	f, _, err := bot.fetcher.fetch(url)
+	if err != nil {
+		return false
+	}
+	f.Close()
+	return true
+}
+
+// stepDownVideos reports false when there is nothing left to try.
+func (bot *bot) stepDownVideos(inputMedias []gotgbot.InputMedia, files []mediaFile, variants videoVariants) bool {
+	stepped := false
+	for i, inputMedia := range inputMedias {
+		variant := mediaVariant(inputMedia)
+		if variant != "video" && variant != "animation" {
+			continue
+		}
+		if lower := bot.lowerVariants(files[i], variants); len(lower) > 0 {
+			log.Println("step down video", files[i].url, lower[0])
+			files[i].url = lower[0]
+			stepped = true
+			continue
+		}
+		if files[i].thumbUrl != "" {
+			log.Println("send video thumbnail", files[i].url)
+			inputMedias[i], files[i] = videoThumbnail(inputMedia, files[i])
+			stepped = true
+		}
+	}
+	return stepped
+}
+
+// by tweet id, so every tweet is looked up once per post
+type videoVariants map[string][][]string
+
+func (bot *bot) lowerVariants(file mediaFile, variants videoVariants) []string {
+	if file.tweetId == "" {
+		return nil
+	}
+	videos, ok := variants[file.tweetId]
+	if !ok {
+		account := bot.accounts.any()
+		if account == nil {
+			return nil
+		}
+		var err error
+		videos, err = account.getVideoVariants(context.Background(), file.tweetId)
+		if err != nil {
+			log.Println("getVideoVariants error", file.tweetId, err)
+		}
+		variants[file.tweetId] = videos
+	}
+	for _, urls := range videos {
+		for j, url := range urls {
+			if clearUrlQueries(url) != file.url {
+				continue
+			}
+			var lower []string
+			for _, url := range urls[j+1:] {
+				lower = append(lower, clearUrlQueries(url))
+			}
+			return lower
+		}
+	}
+	return nil
+}
+
+func videoThumbnail(inputMedia gotgbot.InputMedia, file mediaFile) (gotgbot.InputMedia, mediaFile) {
+	var caption richText
+	var spoiler bool
+	switch m := inputMedia.(type) {
+	case gotgbot.InputMediaVideo:
+		caption = richText{text: m.Caption, entities: m.CaptionEntities}
+		spoiler = m.HasSpoiler
+	case gotgbot.InputMediaAnimation:
+		caption = richText{text: m.Caption, entities: m.CaptionEntities}
+		spoiler = m.HasSpoiler
+	}
+	if file.tweetUrl != "" {
+		var watch richText
+		if caption.len() > 0 {
+			watch.write("\n\n")
+		}
+		watch.link("Watch on X", file.tweetUrl)
+		if caption.len()+watch.len() <= maxCaptionLength {
+			caption.append(watch)
+		}
+	}
+
+	thumb := mediaFile{
+		url:  file.thumbUrl,
+		name: strings.TrimSuffix(file.name, filepath.Ext(file.name)) + filepath.Ext(clearUrlQueries(file.thumbUrl)),
+	}
+	return gotgbot.InputMediaPhoto{
+		Caption:         caption.text,
+		CaptionEntities: caption.entities,
+		HasSpoiler:      spoiler,
+	}, thumb
+}
+
+// the medias of a merged thread are in the order of its posts
+func setMediaSources(files []mediaFile, posts []*entity.ParsedTweet) {
+	i := 0
+	for _, post := range posts {
+		for range post.Entities.Media {
+			if i >= len(files) {
+				return
+			}
+			files[i].tweetId = post.TweetId
+			files[i].tweetUrl = post.Url
+			i++
+		}
+	}
+}